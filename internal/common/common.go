@@ -19,13 +19,63 @@ package common
 import (
 	"fmt"
 	"html"
+	"io/fs"
 	"os"
-	"path"
 	"strings"
+	"sync"
+	"sync/atomic"
+
+	"sigs.k8s.io/verify-conformance/kodata"
 )
 
+// dataFS holds the fs.FS of the data, as atomic.Pointer needs a concrete type,
+// counting the checks which use it so that it is only cleaned up once they are done
+type dataFS struct {
+	fs.FS
+
+	mu sync.Mutex
+	// refs is the number of times the data is acquired and not yet released
+	refs int
+	// replaced is set once other data is active
+	replaced bool
+	// cleanup is called once the data is replaced and no longer acquired
+	cleanup func()
+}
+
+// acquire counts a use of the data, returning false when it has already been replaced
+func (d *dataFS) acquire() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.replaced {
+		return false
+	}
+	d.refs++
+	return true
+}
+
+func (d *dataFS) release() {
+	d.mu.Lock()
+	d.refs--
+	unused := d.replaced && d.refs == 0
+	d.mu.Unlock()
+	if unused && d.cleanup != nil {
+		d.cleanup()
+	}
+}
+
+func (d *dataFS) replace() {
+	d.mu.Lock()
+	d.replaced = true
+	unused := d.refs == 0
+	d.mu.Unlock()
+	if unused && d.cleanup != nil {
+		d.cleanup()
+	}
+}
+
 var (
-	DataPathPrefix = ""
+	// activeDataFS replaces the embedded kodata, with --data-dir or metadata loaded at runtime
+	activeDataFS atomic.Pointer[dataFS]
 )
 
 func Pointer[V any](input V) *V {
@@ -44,17 +94,53 @@ func SafeError(input error) (output error) {
 	return fmt.Errorf("%s", html.EscapeString(input.Error()))
 }
 
-func GetDataPath() string {
-	if val, ok := os.LookupEnv("KO_DATA_PATH"); ok {
-		return val
+// SetDataFS replaces the features, metadata, schemas and conformance metadata with fsys,
+// such as a folder from --data-dir or metadata loaded at runtime, where nil switches back to the embedded kodata
+func SetDataFS(fsys fs.FS) {
+	SetDataFSWithCleanup(fsys, nil)
+}
+
+// SetDataFSWithCleanup is SetDataFS, calling cleanup once fsys has been replaced
+// and every check which acquired it with AcquireDataFS has released it
+func SetDataFSWithCleanup(fsys fs.FS, cleanup func()) {
+	var next *dataFS
+	if fsys != nil {
+		next = &dataFS{FS: fsys, cleanup: cleanup}
+	}
+	if previous := activeDataFS.Swap(next); previous != nil {
+		previous.replace()
+	}
+}
+
+// AcquireDataFS returns the data like GetDataFS, which is kept until the returned release is called,
+// even when other data becomes active in the meantime
+func AcquireDataFS() (fsys fs.FS, release func()) {
+	for {
+		d := activeDataFS.Load()
+		if d == nil {
+			return kodata.FS, func() {}
+		}
+		// the data may be replaced between loading and acquiring it, then the new data is used
+		if d.acquire() {
+			return d.FS, sync.OnceFunc(d.release)
+		}
+	}
+}
+
+// GetDataFS returns the features, metadata, schemas and conformance metadata, laid out like kodata,
+// which are those embedded in the binary unless replaced with SetDataFS
+func GetDataFS() fs.FS {
+	if d := activeDataFS.Load(); d != nil {
+		return d.FS
 	}
-	return path.Join(DataPathPrefix, "./kodata")
+	return kodata.FS
 }
 
-func GetStableTxt() (string, error) {
-	content, err := ReadFile(path.Join(GetDataPath(), "metadata", "stable.txt"))
+// ReadStableTxt returns the latest stable Kubernetes release in the data in fsys
+func ReadStableTxt(fsys fs.FS) (string, error) {
+	content, err := fs.ReadFile(fsys, "metadata/stable.txt")
 	if err != nil {
 		return "", err
 	}
-	return strings.TrimSuffix(content, "\n"), nil
+	return strings.TrimSuffix(string(content), "\n"), nil
 }
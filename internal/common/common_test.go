@@ -19,19 +19,13 @@ package common
 import (
 	_ "embed"
 	"fmt"
-	"log"
-	"os"
+	"io/fs"
 	"reflect"
 	"regexp"
 	"testing"
+	"testing/fstest"
 )
 
-func init() {
-	if err := os.Setenv("KO_DATA_PATH", "./../../kodata"); err != nil {
-		log.Fatalf("failed to set env: %v", err)
-	}
-}
-
 func TestPointer(t *testing.T) {
 	type testCase struct {
 		Name           string
@@ -93,8 +87,8 @@ func TestSafeError(t *testing.T) {
 	}
 }
 
-func TestGetStableTxt(t *testing.T) {
-	version, err := GetStableTxt()
+func TestReadStableTxt(t *testing.T) {
+	version, err := ReadStableTxt(GetDataFS())
 	if err != nil {
 		t.Fatalf("error reading stable.txt: %v", err)
 	}
@@ -108,53 +102,30 @@ func TestGetStableTxt(t *testing.T) {
 	if !re.Match([]byte(version)) {
 		t.Fatalf("error: version (%v) doesn't match regexp", version)
 	}
-	if err := os.Setenv("KO_DATA_PATH", "./../../kodat"); err != nil {
-		t.Fatalf("failed to set env: %v", err)
-	}
-	_, err = GetStableTxt()
+	_, err = ReadStableTxt(fstest.MapFS{})
 	if err == nil {
 		t.Fatalf("error expected to not find stable.txt")
 	}
 }
 
-func TestGetDataPath(t *testing.T) {
-	type testCase struct {
-		Name          string
-		EnvKoDataPath *string
-		ExpectedValue string
+func TestGetDataFS(t *testing.T) {
+	defer SetDataFS(nil)
+	if _, err := fs.Stat(GetDataFS(), "features/verify-conformance.feature"); err != nil {
+		t.Fatalf("error: expected the embedded kodata to have the feature file: %v", err)
 	}
 
-	for _, tc := range []testCase{
-		{
-			Name:          "passing has env set",
-			EnvKoDataPath: Pointer("a"),
-			ExpectedValue: "a",
-		},
-		{
-			Name:          "defaulting no value set",
-			EnvKoDataPath: nil,
-			ExpectedValue: "kodata",
-		},
-	} {
-		checkpointValue := os.Getenv("KO_DATA_PATH")
-
-		if tc.EnvKoDataPath != nil {
-			t.Logf("testcase (%v) setting env to '%v'...", tc.Name, *tc.EnvKoDataPath)
-			if err := os.Setenv("KO_DATA_PATH", *tc.EnvKoDataPath); err != nil {
-				t.Errorf("error restoring env value: %v\n", err)
-			}
-		} else {
-			// nolint:errcheck
-			os.Unsetenv("KO_DATA_PATH")
-		}
-
-		val := GetDataPath()
-		if !reflect.DeepEqual(val, tc.ExpectedValue) {
-			t.Fatalf("error: testcase (%v) datapath value (%v) doesn't equal expected value (%v)", tc.Name, val, tc.ExpectedValue)
-		}
+	SetDataFS(fstest.MapFS{
+		"metadata/stable.txt": &fstest.MapFile{Data: []byte("v1.99.0\n")},
+	})
+	if version, err := ReadStableTxt(GetDataFS()); err != nil || version != "v1.99.0" {
+		t.Fatalf("error: expected stable.txt from the replaced data; got = %v, %v", version, err)
+	}
+	if _, err := fs.Stat(GetDataFS(), "features/verify-conformance.feature"); err == nil {
+		t.Fatalf("error: expected the embedded kodata to be replaced")
+	}
 
-		if err := os.Setenv("KO_DATA_PATH", checkpointValue); err != nil {
-			t.Errorf("error restoring env value: %v\n", err)
-		}
+	SetDataFS(nil)
+	if version, err := ReadStableTxt(GetDataFS()); err != nil || version == "v1.99.0" {
+		t.Fatalf("error: expected stable.txt from the embedded kodata; got = %v, %v", version, err)
 	}
 }
@@ -0,0 +1,256 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metadata syncs the conformance metadata in kodata from upstream Kubernetes,
+// replacing kodata/conformance-testdata only once every file has been fetched and validated.
+package metadata
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/verify-conformance/internal/resolver"
+	"sigs.k8s.io/verify-conformance/internal/suite"
+)
+
+const (
+	// DefaultConformanceBaseURL is where the conformance.yaml of each release branch is fetched from
+	DefaultConformanceBaseURL = "https://raw.githubusercontent.com/kubernetes/kubernetes"
+	// DefaultStableTxtBaseURL is where stable.txt is fetched from
+	DefaultStableTxtBaseURL = "https://dl.k8s.io/release"
+	// ChecksumsFileName is the name of the manifest of checksums written to the conformance metadata folder,
+	// in the format of sha256sum
+	ChecksumsFileName = "SHA256SUMS"
+	// MaxFileSize is the largest file which is fetched, in bytes
+	MaxFileSize = 16 * 1024 * 1024
+
+	conformanceTestdataFolder = "conformance-testdata"
+)
+
+// Options configures Sync, where empty values are replaced with the defaults
+type Options struct {
+	// DataPath is the kodata folder to write to
+	DataPath string
+	// ConformanceBaseURL is the base URL of the Kubernetes repo,
+	// where conformance.yaml is at release-1.x/test/conformance/testdata/conformance.yaml
+	ConformanceBaseURL string
+	// StableTxtBaseURL is the base URL of stable.txt
+	StableTxtBaseURL string
+	// ReleaseLifecycle decides which releases to fetch conformance.yaml for,
+	// read from DataPath when nil
+	ReleaseLifecycle *suite.ReleaseLifecycle
+	// Resolver makes the requests
+	Resolver *resolver.Resolver
+	// Now returns the time the release lifecycle is evaluated at
+	Now func() time.Time
+}
+
+// Result is what Sync wrote
+type Result struct {
+	StableTxt string
+	Releases  []string
+	// Checksums are the sha256 checksums of the files written, by path in the conformance metadata folder
+	Checksums map[string]string
+}
+
+// Sync fetches stable.txt and the conformance.yaml of every supported release, validates them
+// and writes a checksum manifest, before swapping them into the conformance metadata folder.
+// Nothing is changed when any of it fails.
+func Sync(ctx context.Context, opts Options) (*Result, error) {
+	if opts.DataPath == "" {
+		return nil, fmt.Errorf("a data path is required")
+	}
+	if opts.ConformanceBaseURL == "" {
+		opts.ConformanceBaseURL = DefaultConformanceBaseURL
+	}
+	if opts.StableTxtBaseURL == "" {
+		opts.StableTxtBaseURL = DefaultStableTxtBaseURL
+	}
+	if opts.Resolver == nil {
+		opts.Resolver = resolver.New(resolver.Options{})
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	if opts.ReleaseLifecycle == nil {
+		content, err := os.ReadFile(filepath.Join(opts.DataPath, "metadata", "release-lifecycle.yaml"))
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the release lifecycle, %v", err)
+		}
+		opts.ReleaseLifecycle, err = suite.ParseReleaseLifecycle(content)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	stableTxtContents, err := fetch(ctx, opts.Resolver, strings.TrimSuffix(opts.StableTxtBaseURL, "/")+"/stable.txt")
+	if err != nil {
+		return nil, err
+	}
+	stableTxt := strings.TrimSpace(string(stableTxtContents))
+	stable, err := suite.ParseReleaseVersion(stableTxt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid stable.txt, %v", err)
+	}
+
+	result := &Result{StableTxt: stableTxt, Checksums: map[string]string{}}
+	conformanceYAMLs := map[string][]byte{}
+	for _, release := range opts.ReleaseLifecycle.SupportedReleases(stable, opts.Now()) {
+		uri := fmt.Sprintf("%v/release-%v.%v/test/conformance/testdata/conformance.yaml", strings.TrimSuffix(opts.ConformanceBaseURL, "/"), release.Major, release.Minor)
+		contents, err := fetch(ctx, opts.Resolver, uri)
+		if err != nil {
+			return nil, err
+		}
+		if err := ValidateConformanceYAML(contents); err != nil {
+			return nil, fmt.Errorf("invalid conformance.yaml for %v from '%v', %v", release.MinorVersion(), uri, err)
+		}
+		name := filepath.ToSlash(filepath.Join(release.MinorVersion(), "conformance.yaml"))
+		conformanceYAMLs[name] = contents
+		result.Releases = append(result.Releases, release.MinorVersion())
+		result.Checksums[name] = fmt.Sprintf("%x", sha256.Sum256(contents))
+	}
+	if len(conformanceYAMLs) == 0 {
+		return nil, fmt.Errorf("no releases are supported for stable.txt %v", stableTxt)
+	}
+
+	if err := writeConformanceTestdata(filepath.Join(opts.DataPath, conformanceTestdataFolder), conformanceYAMLs, result.Checksums); err != nil {
+		return nil, err
+	}
+	if err := writeFileAtomically(filepath.Join(opts.DataPath, "metadata", "stable.txt"), []byte(stableTxt+"\n")); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ValidateConformanceYAML checks that contents is a list of conformance test metadata
+func ValidateConformanceYAML(contents []byte) error {
+	var conformanceMetadata []suite.ConformanceTestMetadata
+	if err := yaml.Unmarshal(contents, &conformanceMetadata); err != nil {
+		return err
+	}
+	if len(conformanceMetadata) == 0 {
+		return fmt.Errorf("no tests found")
+	}
+	for i, m := range conformanceMetadata {
+		if m.Codename == "" {
+			return fmt.Errorf("the test at index %v has no codename", i)
+		}
+	}
+	return nil
+}
+
+func fetch(ctx context.Context, r *resolver.Resolver, uri string) ([]byte, error) {
+	result := r.Fetch(ctx, uri, MaxFileSize)
+	if result.Error != "" {
+		return nil, fmt.Errorf("unable to fetch '%v', %v", uri, result.Error)
+	}
+	return result.Contents, nil
+}
+
+// checksumsManifest returns checksums in the format of sha256sum, sorted by path
+func checksumsManifest(checksums map[string]string) []byte {
+	names := []string{}
+	for name := range checksums {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	manifest := ""
+	for _, name := range names {
+		manifest += fmt.Sprintf("%v  %v\n", checksums[name], name)
+	}
+	return []byte(manifest)
+}
+
+// writeConformanceTestdata writes files and their checksums into a new folder beside folder,
+// then swaps it with folder, restoring the previous folder when the swap fails
+func writeConformanceTestdata(folder string, files map[string][]byte, checksums map[string]string) (err error) {
+	parent := filepath.Dir(folder)
+	staging, err := os.MkdirTemp(parent, "."+filepath.Base(folder)+"-new-")
+	if err != nil {
+		return fmt.Errorf("unable to create a folder to write the conformance metadata to, %v", err)
+	}
+	defer func() {
+		_ = os.RemoveAll(staging)
+	}()
+	for name, contents := range files {
+		p := filepath.Join(staging, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(p, contents, 0644); err != nil {
+			return err
+		}
+	}
+	if err := os.WriteFile(filepath.Join(staging, ChecksumsFileName), checksumsManifest(checksums), 0644); err != nil {
+		return err
+	}
+	if err := os.Chmod(staging, 0755); err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(folder); os.IsNotExist(err) {
+		return os.Rename(staging, folder)
+	}
+	previous, err := os.MkdirTemp(parent, "."+filepath.Base(folder)+"-old-")
+	if err != nil {
+		return fmt.Errorf("unable to create a folder to move the previous conformance metadata to, %v", err)
+	}
+	defer func() {
+		_ = os.RemoveAll(previous)
+	}()
+	// the previous folder is moved aside, as a rename won't replace a folder which isn't empty
+	backup := filepath.Join(previous, filepath.Base(folder))
+	if err := os.Rename(folder, backup); err != nil {
+		return fmt.Errorf("unable to move the previous conformance metadata aside, %v", err)
+	}
+	if err := os.Rename(staging, folder); err != nil {
+		if restoreErr := os.Rename(backup, folder); restoreErr != nil {
+			return fmt.Errorf("unable to swap in the conformance metadata, %v; and unable to restore the previous conformance metadata from '%v', %v", err, backup, restoreErr)
+		}
+		return fmt.Errorf("unable to swap in the conformance metadata, %v", err)
+	}
+	return nil
+}
+
+// writeFileAtomically writes contents to a temporary file beside name before renaming it over name
+func writeFileAtomically(name string, contents []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(name), "."+filepath.Base(name)+"-")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Remove(f.Name())
+	}()
+	if _, err := f.Write(contents); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(f.Name(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), name)
+}
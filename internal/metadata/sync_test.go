@@ -0,0 +1,249 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metadata
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/verify-conformance/internal/resolver"
+	"sigs.k8s.io/verify-conformance/internal/suite"
+)
+
+const testConformanceYAML = `- testname: Pod Lifecycle
+  codename: '[sig-node] Pods should be submitted and removed [NodeConformance] [Conformance]'
+  description: Create a Pod, it MUST be submitted and removed.
+  release: v1.9
+  file: test/e2e/common/node/pods.go
+`
+
+var testReleaseLifecycle = &suite.ReleaseLifecycle{
+	SupportedMinors: 3,
+	GracePeriodDays: 60,
+	Releases: []suite.ReleaseLifecycleRelease{
+		{Version: "v1.35", ReleaseDate: "2025-12-17"},
+		{Version: "v1.36", ReleaseDate: "2026-04-22"},
+	},
+}
+
+// newTestMirror serves stable.txt and the conformance.yaml of each release in files
+func newTestMirror(stableTxt string, files map[string]string) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/release/stable.txt", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte(stableTxt + "\n"))
+	})
+	for release, contents := range files {
+		contents := contents
+		mux.HandleFunc(fmt.Sprintf("/kubernetes/release-%v/test/conformance/testdata/conformance.yaml", release), func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = w.Write([]byte(contents))
+		})
+	}
+	return httptest.NewServer(mux)
+}
+
+func newTestDataPath(t *testing.T) string {
+	dataPath := t.TempDir()
+	for name, contents := range map[string]string{
+		"metadata/stable.txt":                         "v1.35.0\n",
+		"conformance-testdata/v1.33/conformance.yaml": testConformanceYAML,
+		"conformance-testdata/v1.34/conformance.yaml": testConformanceYAML,
+		"conformance-testdata/v1.35/conformance.yaml": testConformanceYAML,
+	} {
+		p := filepath.Join(dataPath, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatalf("error: %v", err)
+		}
+		if err := os.WriteFile(p, []byte(contents), 0644); err != nil {
+			t.Fatalf("error: %v", err)
+		}
+	}
+	return dataPath
+}
+
+func TestSync(t *testing.T) {
+	checksum := fmt.Sprintf("%x", sha256.Sum256([]byte(testConformanceYAML)))
+
+	type testCase struct {
+		Name                string
+		StableTxt           string
+		Files               map[string]string
+		ExpectedErrorString string
+		ExpectedFiles       []string
+		ExpectedStableTxt   string
+		ExpectedReleases    []string
+		Now                 time.Time
+	}
+
+	for _, tc := range []testCase{
+		{
+			Name:      "supported releases are synced",
+			StableTxt: "v1.36.0",
+			Files: map[string]string{
+				"1.34": testConformanceYAML,
+				"1.35": testConformanceYAML,
+				"1.36": testConformanceYAML,
+			},
+			ExpectedFiles:     []string{"SHA256SUMS", "v1.34/conformance.yaml", "v1.35/conformance.yaml", "v1.36/conformance.yaml"},
+			ExpectedStableTxt: "v1.36.0\n",
+			ExpectedReleases:  []string{"v1.34", "v1.35", "v1.36"},
+		},
+		{
+			Name:      "the release in the grace period is synced",
+			StableTxt: "v1.36.0",
+			Files: map[string]string{
+				"1.33": testConformanceYAML,
+				"1.34": testConformanceYAML,
+				"1.35": testConformanceYAML,
+				"1.36": testConformanceYAML,
+			},
+			Now:               time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC),
+			ExpectedFiles:     []string{"SHA256SUMS", "v1.33/conformance.yaml", "v1.34/conformance.yaml", "v1.35/conformance.yaml", "v1.36/conformance.yaml"},
+			ExpectedStableTxt: "v1.36.0\n",
+			ExpectedReleases:  []string{"v1.33", "v1.34", "v1.35", "v1.36"},
+		},
+		{
+			Name:      "missing conformance.yaml",
+			StableTxt: "v1.36.0",
+			Files: map[string]string{
+				"1.34": testConformanceYAML,
+				"1.36": testConformanceYAML,
+			},
+			ExpectedErrorString: "/release-1.35/test/conformance/testdata/conformance.yaml', the server responded with status 404 Not Found",
+			ExpectedFiles:       []string{"v1.33/conformance.yaml", "v1.34/conformance.yaml", "v1.35/conformance.yaml"},
+			ExpectedStableTxt:   "v1.35.0\n",
+		},
+		{
+			Name:      "invalid conformance.yaml",
+			StableTxt: "v1.36.0",
+			Files: map[string]string{
+				"1.34": testConformanceYAML,
+				"1.35": "<html>not found</html>",
+				"1.36": testConformanceYAML,
+			},
+			ExpectedErrorString: "invalid conformance.yaml for v1.35",
+			ExpectedFiles:       []string{"v1.33/conformance.yaml", "v1.34/conformance.yaml", "v1.35/conformance.yaml"},
+			ExpectedStableTxt:   "v1.35.0\n",
+		},
+		{
+			Name:                "invalid stable.txt",
+			StableTxt:           "<html>not found</html>",
+			ExpectedErrorString: "invalid stable.txt",
+			ExpectedFiles:       []string{"v1.33/conformance.yaml", "v1.34/conformance.yaml", "v1.35/conformance.yaml"},
+			ExpectedStableTxt:   "v1.35.0\n",
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			svr := newTestMirror(tc.StableTxt, tc.Files)
+			defer svr.Close()
+			dataPath := newTestDataPath(t)
+			now := tc.Now
+			if now.IsZero() {
+				now = time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+			}
+
+			result, err := Sync(context.Background(), Options{
+				DataPath:           dataPath,
+				ConformanceBaseURL: svr.URL + "/kubernetes",
+				StableTxtBaseURL:   svr.URL + "/release",
+				ReleaseLifecycle:   testReleaseLifecycle,
+				Resolver:           resolver.New(resolver.Options{AllowPrivateAddresses: true}),
+				Now:                func() time.Time { return now },
+			})
+			if tc.ExpectedErrorString == "" && err != nil {
+				t.Fatalf("error: unexpected error: %v", err)
+			}
+			if tc.ExpectedErrorString != "" && (err == nil || !strings.Contains(err.Error(), tc.ExpectedErrorString)) {
+				t.Fatalf("error: expected error containing '%v'; got = %v", tc.ExpectedErrorString, err)
+			}
+			if files, _ := listFiles(filepath.Join(dataPath, "conformance-testdata")); !reflect.DeepEqual(files, tc.ExpectedFiles) {
+				t.Fatalf("error: unexpected files;\nwant = %v\ngot  = %v", tc.ExpectedFiles, files)
+			}
+			if stableTxt, _ := os.ReadFile(filepath.Join(dataPath, "metadata", "stable.txt")); string(stableTxt) != tc.ExpectedStableTxt {
+				t.Fatalf("error: unexpected stable.txt; want = %q; got = %q", tc.ExpectedStableTxt, stableTxt)
+			}
+			entries, _ := os.ReadDir(dataPath)
+			for _, e := range entries {
+				if strings.HasPrefix(e.Name(), ".") {
+					t.Fatalf("error: expected the temporary folder '%v' to be removed", e.Name())
+				}
+			}
+			if err != nil {
+				return
+			}
+			if !reflect.DeepEqual(result.Releases, tc.ExpectedReleases) {
+				t.Fatalf("error: unexpected releases; want = %v; got = %v", tc.ExpectedReleases, result.Releases)
+			}
+			manifest, _ := os.ReadFile(filepath.Join(dataPath, "conformance-testdata", ChecksumsFileName))
+			expectedManifest := ""
+			for _, release := range tc.ExpectedReleases {
+				expectedManifest += fmt.Sprintf("%v  %v/conformance.yaml\n", checksum, release)
+			}
+			if string(manifest) != expectedManifest {
+				t.Fatalf("error: unexpected checksum manifest;\nwant = %v\ngot  = %v", expectedManifest, string(manifest))
+			}
+		})
+	}
+}
+
+func TestValidateConformanceYAML(t *testing.T) {
+	for _, tc := range []struct {
+		Name                string
+		Contents            string
+		ExpectedErrorString string
+	}{
+		{
+			Name:     "valid",
+			Contents: testConformanceYAML,
+		},
+		{
+			Name:                "not a list",
+			Contents:            "testname: Pod Lifecycle\n",
+			ExpectedErrorString: "cannot unmarshal",
+		},
+		{
+			Name:                "empty",
+			Contents:            "[]\n",
+			ExpectedErrorString: "no tests found",
+		},
+		{
+			Name:                "missing codename",
+			Contents:            "- testname: Pod Lifecycle\n",
+			ExpectedErrorString: "the test at index 0 has no codename",
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			err := ValidateConformanceYAML([]byte(tc.Contents))
+			if tc.ExpectedErrorString == "" && err != nil {
+				t.Fatalf("error: unexpected error: %v", err)
+			}
+			if tc.ExpectedErrorString != "" && (err == nil || !strings.Contains(err.Error(), tc.ExpectedErrorString)) {
+				t.Fatalf("error: expected error containing '%v'; got = %v", tc.ExpectedErrorString, err)
+			}
+		})
+	}
+}
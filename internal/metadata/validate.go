@@ -0,0 +1,114 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metadata
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+
+	"sigs.k8s.io/verify-conformance/internal/suite"
+)
+
+// Validate checks that the data in fsys, laid out like kodata, has a valid stable.txt, release lifecycle,
+// conformance.yaml for each release matching the checksum manifest when there is one,
+// feature files which only use defined steps, comment templates which render and a PRODUCT.yaml schema which compiles
+func Validate(fsys fs.FS) error {
+	stableTxt, err := fs.ReadFile(fsys, "metadata/stable.txt")
+	if err != nil {
+		return fmt.Errorf("unable to read stable.txt, %v", err)
+	}
+	if _, err := suite.ParseReleaseVersion(strings.TrimSpace(string(stableTxt))); err != nil {
+		return fmt.Errorf("invalid stable.txt, %v", err)
+	}
+	releaseLifecycle, err := fs.ReadFile(fsys, "metadata/release-lifecycle.yaml")
+	if err != nil {
+		return fmt.Errorf("unable to read the release lifecycle, %v", err)
+	}
+	if _, err := suite.ParseReleaseLifecycle(releaseLifecycle); err != nil {
+		return err
+	}
+	if err := validateConformanceTestdata(fsys, conformanceTestdataFolder); err != nil {
+		return err
+	}
+	if err := suite.ValidateFeatures(fsys, []string{suite.FeaturesFolder}); err != nil {
+		return fmt.Errorf("invalid feature files, %v", err)
+	}
+	if err := suite.ValidateCommentTemplates(fsys); err != nil {
+		return fmt.Errorf("invalid comment templates, %v", err)
+	}
+	if err := suite.ValidateProductYAMLSchema(fsys); err != nil {
+		return fmt.Errorf("invalid PRODUCT.yaml schema, %v", err)
+	}
+	return nil
+}
+
+// validateConformanceTestdata checks the conformance.yaml in each release folder of folder in fsys
+// and that they match the checksum manifest, when there is one
+func validateConformanceTestdata(fsys fs.FS, folder string) error {
+	entries, err := fs.ReadDir(fsys, folder)
+	if err != nil {
+		return fmt.Errorf("unable to read the conformance metadata, %v", err)
+	}
+	checksums := map[string]string{}
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		release, err := suite.ParseReleaseVersion(e.Name())
+		if err != nil || release.HasPatch {
+			return fmt.Errorf("the conformance metadata folder '%v' must be a minor release version like v1.35", e.Name())
+		}
+		name := path.Join(e.Name(), "conformance.yaml")
+		contents, err := fs.ReadFile(fsys, path.Join(folder, name))
+		if err != nil {
+			return fmt.Errorf("unable to read the conformance.yaml for %v, %v", e.Name(), err)
+		}
+		if err := ValidateConformanceYAML(contents); err != nil {
+			return fmt.Errorf("invalid conformance.yaml for %v, %v", e.Name(), err)
+		}
+		checksums[name] = fmt.Sprintf("%x", sha256.Sum256(contents))
+	}
+	if len(checksums) == 0 {
+		return fmt.Errorf("no conformance.yaml found")
+	}
+
+	manifest, err := fs.ReadFile(fsys, path.Join(folder, ChecksumsFileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("unable to read %v, %v", ChecksumsFileName, err)
+	}
+	for _, line := range strings.Split(strings.TrimSpace(string(manifest)), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return fmt.Errorf("invalid line in %v '%v'", ChecksumsFileName, line)
+		}
+		checksum, found := checksums[fields[1]]
+		if !found {
+			return fmt.Errorf("the file '%v' in %v is missing", fields[1], ChecksumsFileName)
+		}
+		if checksum != fields[0] {
+			return fmt.Errorf("the checksum of '%v' doesn't match %v", fields[1], ChecksumsFileName)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,262 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metadata
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/verify-conformance/internal/common"
+)
+
+const (
+	// DefaultWatchPeriod is how often the metadata folder is checked for changes
+	DefaultWatchPeriod = time.Minute
+)
+
+// WatcherOptions configures a Watcher, where empty values are replaced with the defaults
+type WatcherOptions struct {
+	// Dir is the metadata folder to watch, laid out like kodata, such as a mounted ConfigMap.
+	// Each top-level folder in it, such as features or conformance-testdata, replaces that of kodata.
+	Dir string
+	// Period is how often Dir is checked for changes
+	Period time.Duration
+	// SnapshotDir is where validated copies of the metadata are kept, the system temporary folder by default
+	SnapshotDir string
+	// OnSwitch is called after switching to new metadata
+	OnSwitch func()
+	// Base is the data the folders of Dir are laid over, the data in use when the Watcher is created by default
+	Base fs.FS
+}
+
+// Watcher switches the data to the metadata in a folder whenever it changes and is valid,
+// keeping the last good metadata when it isn't
+type Watcher struct {
+	log     *logrus.Entry
+	options WatcherOptions
+
+	mu sync.Mutex
+	// fingerprint is of the last contents of the folder which were validated, accepted or not
+	fingerprint string
+	// current is the snapshot in use, which is removed once replaced and no check uses it
+	current string
+}
+
+// NewWatcher returns a Watcher for the metadata folder in options
+func NewWatcher(log *logrus.Entry, options WatcherOptions) *Watcher {
+	if options.Period <= 0 {
+		options.Period = DefaultWatchPeriod
+	}
+	if options.SnapshotDir == "" {
+		options.SnapshotDir = os.TempDir()
+	}
+	if options.Base == nil {
+		options.Base = common.GetDataFS()
+	}
+	return &Watcher{
+		log:     log.WithField("metadata-dir", options.Dir),
+		options: options,
+	}
+}
+
+// Run checks the metadata folder once per period until ctx is done
+func (w *Watcher) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(w.options.Period):
+		}
+		w.Load()
+	}
+}
+
+// Load switches to the metadata in the folder when it has changed since the last check and is valid,
+// returning whether it switched. Rejected metadata is logged and the last good metadata stays active.
+func (w *Watcher) Load() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	files, err := listFiles(w.options.Dir)
+	if err != nil {
+		w.log.WithError(err).Error("Unable to read the metadata folder, keeping the last good metadata.")
+		return false
+	}
+	fingerprint, err := fingerprintFiles(w.options.Dir, files)
+	if err != nil {
+		w.log.WithError(err).Error("Unable to read the metadata folder, keeping the last good metadata.")
+		return false
+	}
+	if fingerprint == w.fingerprint {
+		return false
+	}
+
+	// the fingerprint is only kept once the metadata is validated, so that failing to copy it is retried
+	snapshot, err := w.snapshot(files)
+	if err != nil {
+		w.log.WithError(err).Error("Unable to copy the metadata, keeping the last good metadata.")
+		return false
+	}
+	if err := Validate(os.DirFS(snapshot)); err != nil {
+		_ = os.RemoveAll(snapshot)
+		w.fingerprint = fingerprint
+		w.log.WithError(err).Error("Rejected the updated metadata, keeping the last good metadata.")
+		return false
+	}
+	w.fingerprint = fingerprint
+
+	// the previous snapshot is removed once the checks which acquired it are done
+	common.SetDataFSWithCleanup(os.DirFS(snapshot), func() {
+		if err := os.RemoveAll(snapshot); err != nil {
+			w.log.WithError(err).WithField("snapshot", snapshot).Error("Unable to remove the replaced metadata.")
+		}
+	})
+	w.current = snapshot
+	w.log.WithField("snapshot", snapshot).Info("Switched to the updated metadata.")
+	if w.options.OnSwitch != nil {
+		w.options.OnSwitch()
+	}
+	return true
+}
+
+// snapshot copies the base data with each top-level folder in files replacing that of it,
+// so that the metadata doesn't change while it is validated and used
+func (w *Watcher) snapshot(files []string) (snapshot string, err error) {
+	snapshot, err = os.MkdirTemp(w.options.SnapshotDir, "verify-conformance-metadata-")
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if err != nil {
+			_ = os.RemoveAll(snapshot)
+		}
+	}()
+
+	replaced := map[string]bool{}
+	for _, f := range files {
+		replaced[strings.SplitN(f, "/", 2)[0]] = true
+	}
+	err = fs.WalkDir(w.options.Base, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if replaced[strings.SplitN(name, "/", 2)[0]] {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+		return copyFile(w.options.Base, name, filepath.Join(snapshot, filepath.FromSlash(name)))
+	})
+	if err != nil {
+		return "", err
+	}
+	dir := os.DirFS(w.options.Dir)
+	for _, f := range files {
+		if err := copyFile(dir, f, filepath.Join(snapshot, filepath.FromSlash(f))); err != nil {
+			return "", err
+		}
+	}
+	return snapshot, nil
+}
+
+// listFiles returns the files in dir relative to it, sorted, following symlinks and
+// skipping hidden files, such as the ..data folder of a mounted ConfigMap
+func listFiles(dir string) ([]string, error) {
+	files := []string{}
+	var walk func(rel string) error
+	walk = func(rel string) error {
+		entries, err := os.ReadDir(filepath.Join(dir, filepath.FromSlash(rel)))
+		if err != nil {
+			return err
+		}
+		for _, e := range entries {
+			if strings.HasPrefix(e.Name(), ".") {
+				continue
+			}
+			name := strings.TrimPrefix(rel+"/"+e.Name(), "/")
+			info, err := os.Stat(filepath.Join(dir, filepath.FromSlash(name)))
+			if err != nil {
+				return err
+			}
+			if info.IsDir() {
+				if err := walk(name); err != nil {
+					return err
+				}
+				continue
+			}
+			files = append(files, name)
+		}
+		return nil
+	}
+	if err := walk(""); err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// fingerprintFiles returns a checksum of the names and contents of files in dir
+func fingerprintFiles(dir string, files []string) (string, error) {
+	h := sha256.New()
+	for _, f := range files {
+		contents, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(f)))
+		if err != nil {
+			return "", err
+		}
+		_, _ = fmt.Fprintf(h, "%v %v\n", f, len(contents))
+		_, _ = h.Write(contents)
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
+
+// copyFile copies the file name in fsys to dst
+func copyFile(fsys fs.FS, name, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	in, err := fsys.Open(name)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = in.Close()
+	}()
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
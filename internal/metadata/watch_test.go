@@ -0,0 +1,291 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metadata
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/verify-conformance/internal/common"
+	"sigs.k8s.io/verify-conformance/internal/suite"
+)
+
+// copyTestKodata copies the folder of the embedded kodata to dir
+func copyTestKodata(t *testing.T, folder, dir string) {
+	err := fs.WalkDir(common.GetDataFS(), folder, func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		return copyFile(common.GetDataFS(), name, filepath.Join(dir, filepath.FromSlash(name)))
+	})
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+}
+
+func writeTestFile(t *testing.T, name, contents string) {
+	if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if err := os.WriteFile(name, []byte(contents), 0644); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	type testCase struct {
+		Name                string
+		Prepare             func(t *testing.T, dataPath string)
+		ExpectedErrorString string
+	}
+
+	for _, tc := range []testCase{
+		{
+			Name: "valid",
+		},
+		{
+			Name: "invalid stable.txt",
+			Prepare: func(t *testing.T, dataPath string) {
+				writeTestFile(t, filepath.Join(dataPath, "metadata", "stable.txt"), "<html>not found</html>")
+			},
+			ExpectedErrorString: "invalid stable.txt",
+		},
+		{
+			Name: "missing release lifecycle",
+			Prepare: func(t *testing.T, dataPath string) {
+				_ = os.Remove(filepath.Join(dataPath, "metadata", "release-lifecycle.yaml"))
+			},
+			ExpectedErrorString: "unable to read the release lifecycle",
+		},
+		{
+			Name: "invalid conformance.yaml",
+			Prepare: func(t *testing.T, dataPath string) {
+				writeTestFile(t, filepath.Join(dataPath, "conformance-testdata", "v1.37", "conformance.yaml"), "[]\n")
+			},
+			ExpectedErrorString: "invalid conformance.yaml for v1.37, no tests found",
+		},
+		{
+			Name: "conformance.yaml not matching the checksum manifest",
+			Prepare: func(t *testing.T, dataPath string) {
+				writeTestFile(t, filepath.Join(dataPath, "conformance-testdata", "v1.35", "conformance.yaml"), testConformanceYAML)
+			},
+			ExpectedErrorString: "the checksum of 'v1.35/conformance.yaml' doesn't match SHA256SUMS",
+		},
+		{
+			Name: "release folder with a patch",
+			Prepare: func(t *testing.T, dataPath string) {
+				writeTestFile(t, filepath.Join(dataPath, "conformance-testdata", "v1.35.2", "conformance.yaml"), testConformanceYAML)
+			},
+			ExpectedErrorString: "the conformance metadata folder 'v1.35.2' must be a minor release version",
+		},
+		{
+			Name: "undefined step in a feature file",
+			Prepare: func(t *testing.T, dataPath string) {
+				writeTestFile(t, filepath.Join(dataPath, "features", "extra.feature"), "Feature: extra\n\n  Scenario: soup\n    Given a bowl of soup\n")
+			},
+			ExpectedErrorString: "'Given a bowl of soup' in the scenario 'soup'",
+		},
+		{
+			Name: "comment template with an unknown field",
+			Prepare: func(t *testing.T, dataPath string) {
+				writeTestFile(t, filepath.Join(dataPath, "templates", "draft.md.tmpl"), "{{ .Soup }}\n")
+			},
+			ExpectedErrorString: "unable to render the comment template 'draft.md.tmpl'",
+		},
+		{
+			Name: "missing comment template",
+			Prepare: func(t *testing.T, dataPath string) {
+				_ = os.Remove(filepath.Join(dataPath, "templates", "draft.md.tmpl"))
+			},
+			ExpectedErrorString: "missing the comment template 'draft.md.tmpl'",
+		},
+		{
+			Name: "PRODUCT.yaml schema which doesn't compile",
+			Prepare: func(t *testing.T, dataPath string) {
+				writeTestFile(t, filepath.Join(dataPath, "schemas", "product-yaml-v1.schema.json"), `{"type": "soup"}`)
+			},
+			ExpectedErrorString: "invalid PRODUCT.yaml schema, unable to compile PRODUCT.yaml schema",
+		},
+		{
+			Name: "missing PRODUCT.yaml schema",
+			Prepare: func(t *testing.T, dataPath string) {
+				_ = os.Remove(filepath.Join(dataPath, "schemas", "product-yaml-v1.schema.json"))
+			},
+			ExpectedErrorString: "invalid PRODUCT.yaml schema, unable to read PRODUCT.yaml schema",
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			dataPath := t.TempDir()
+			for _, folder := range []string{"metadata", "conformance-testdata", "features", "templates", "schemas"} {
+				copyTestKodata(t, folder, dataPath)
+			}
+			if tc.Prepare != nil {
+				tc.Prepare(t, dataPath)
+			}
+			err := Validate(os.DirFS(dataPath))
+			if tc.ExpectedErrorString == "" && err != nil {
+				t.Fatalf("error: unexpected error: %v", err)
+			}
+			if tc.ExpectedErrorString != "" && (err == nil || !strings.Contains(err.Error(), tc.ExpectedErrorString)) {
+				t.Fatalf("error: expected error containing '%v'; got = %v", tc.ExpectedErrorString, err)
+			}
+		})
+	}
+}
+
+func TestWatcherLoad(t *testing.T) {
+	defer common.SetDataFS(nil)
+
+	dir := t.TempDir()
+	copyTestKodata(t, "features", dir)
+	switches := 0
+	w := NewWatcher(logrus.NewEntry(logrus.StandardLogger()), WatcherOptions{
+		Dir:         dir,
+		SnapshotDir: t.TempDir(),
+		OnSwitch:    func() { switches++ },
+	})
+
+	if !w.Load() {
+		t.Fatalf("error: expected to switch to the metadata")
+	}
+	first := w.current
+	if _, err := fs.Stat(common.GetDataFS(), "features/verify-conformance.feature"); err != nil || first == "" {
+		t.Fatalf("error: expected the snapshot to be active: %v", err)
+	}
+	// folders not in the metadata folder come from kodata
+	if _, err := os.Stat(filepath.Join(first, "conformance-testdata", "v1.35", "conformance.yaml")); err != nil {
+		t.Fatalf("error: expected the conformance metadata of kodata in the snapshot: %v", err)
+	}
+	if w.Load() {
+		t.Fatalf("error: expected not to switch when the metadata is unchanged")
+	}
+
+	writeTestFile(t, filepath.Join(dir, "features", "extra.feature"), "Feature: extra\n\n  Scenario: soup\n    Given a bowl of soup\n")
+	if w.Load() {
+		t.Fatalf("error: expected the invalid metadata to be rejected")
+	}
+	if _, err := fs.Stat(common.GetDataFS(), "features/extra.feature"); err == nil || w.current != first {
+		t.Fatalf("error: expected the last good metadata to stay active; got = %v", w.current)
+	}
+
+	writeTestFile(t, filepath.Join(dir, "features", "extra.feature"), "Feature: extra\n\n  Scenario: title\n    Given a PR title\n    Then the PR title is not empty\n")
+	// a check in flight keeps the metadata it started with
+	inFlight, release := common.AcquireDataFS()
+	if !w.Load() {
+		t.Fatalf("error: expected to switch to the fixed metadata")
+	}
+	second := w.current
+	if second == first {
+		t.Fatalf("error: expected a new snapshot; got = %v", second)
+	}
+	if _, err := fs.Stat(common.GetDataFS(), "features/extra.feature"); err != nil {
+		t.Fatalf("error: expected the new feature file in the snapshot: %v", err)
+	}
+	if _, err := fs.Stat(inFlight, "features/verify-conformance.feature"); err != nil {
+		t.Fatalf("error: expected the metadata of the check in flight to be kept: %v", err)
+	}
+	release()
+	if _, err := os.Stat(first); !os.IsNotExist(err) {
+		t.Fatalf("error: expected the replaced metadata to be removed once the check is done")
+	}
+
+	writeTestFile(t, filepath.Join(dir, "features", "extra.feature"), "Feature: extra\n\n  Scenario: title\n    Given a PR title\n")
+	if !w.Load() {
+		t.Fatalf("error: expected to switch to the updated metadata")
+	}
+	if _, err := os.Stat(second); !os.IsNotExist(err) {
+		t.Fatalf("error: expected the replaced metadata without checks to be removed")
+	}
+	if switches != 3 {
+		t.Fatalf("error: expected 3 switches; got = %v", switches)
+	}
+}
+
+func TestWatcherLoadRetriesCopy(t *testing.T) {
+	defer common.SetDataFS(nil)
+
+	dir := t.TempDir()
+	copyTestKodata(t, "features", dir)
+	snapshotDir := filepath.Join(t.TempDir(), "snapshots")
+	w := NewWatcher(logrus.NewEntry(logrus.StandardLogger()), WatcherOptions{
+		Dir:         dir,
+		SnapshotDir: snapshotDir,
+	})
+
+	// the snapshot folder is missing, like a temporary failure to copy
+	if w.Load() {
+		t.Fatalf("error: expected not to switch when the metadata can't be copied")
+	}
+	if err := os.MkdirAll(snapshotDir, 0755); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if !w.Load() {
+		t.Fatalf("error: expected to retry the unchanged metadata once it can be copied")
+	}
+}
+
+func TestWatcherLoadRejectsInvalidSchema(t *testing.T) {
+	defer common.SetDataFS(nil)
+
+	dir := t.TempDir()
+	copyTestKodata(t, "schemas", dir)
+	w := NewWatcher(logrus.NewEntry(logrus.StandardLogger()), WatcherOptions{
+		Dir:         dir,
+		SnapshotDir: t.TempDir(),
+	})
+	if !w.Load() {
+		t.Fatalf("error: expected to switch to the metadata")
+	}
+	first := w.current
+
+	writeTestFile(t, filepath.Join(dir, "schemas", "product-yaml-v1.schema.json"), `{"type": "soup"}`)
+	if w.Load() {
+		t.Fatalf("error: expected the metadata with a PRODUCT.yaml schema which doesn't compile to be rejected")
+	}
+	if err := suite.ValidateProductYAMLSchema(common.GetDataFS()); err != nil || w.current != first {
+		t.Fatalf("error: expected the last good PRODUCT.yaml schema to stay active: %v", err)
+	}
+}
+
+func TestListFilesConfigMap(t *testing.T) {
+	// a mounted ConfigMap links each key to the ..data folder, which links to the current version
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "..2026_10_17", "metadata", "stable.txt"), "v1.36.0\n")
+	writeTestFile(t, filepath.Join(dir, "..2026_10_17", "features", "a.feature"), "Feature: a\n")
+	for oldname, newname := range map[string]string{
+		"..2026_10_17":    "..data",
+		"..data/metadata": "metadata",
+		"..data/features": "features",
+	} {
+		if err := os.Symlink(oldname, filepath.Join(dir, newname)); err != nil {
+			t.Fatalf("error: %v", err)
+		}
+	}
+	files, err := listFiles(dir)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if expected := []string{"features/a.feature", "metadata/stable.txt"}; !reflect.DeepEqual(files, expected) {
+		t.Fatalf("error: unexpected files; want = %v; got = %v", expected, files)
+	}
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/prow/pkg/github"
+
+	"sigs.k8s.io/verify-conformance/internal/suite"
+)
+
+const (
+	checkRunName = "verify-conformance"
+	// checkRunMaxAnnotations is the most annotations the Checks API accepts in a request
+	checkRunMaxAnnotations = 50
+	// checkRunMaxSummaryLength is the most characters the Checks API accepts in a summary
+	checkRunMaxSummaryLength = 65535
+	// draftResultDescription describes the pending result of a draft, replacing the result from before it was a draft
+	draftResultDescription = "Checks are held off until the PR is ready for review"
+)
+
+// UseChecksAPI reports the results as a check run with annotations instead of a commit status.
+// The Checks API is only available to GitHub Apps, with the checks write permission.
+var UseChecksAPI = false
+
+// checkRunConclusions maps the state of a report to the conclusion of a check run
+var checkRunConclusions = map[string]string{
+	"success": "success",
+	"failure": "failure",
+	"pending": "neutral",
+}
+
+// updateResult reports the state of report on the head commit of the PR,
+// as a check run when UseChecksAPI is set or else as a commit status
+func updateResult(log *logrus.Entry, ghc githubClient, pr *suite.PullRequestQuery, prSuite *suite.PRSuite, report *suite.Report) error {
+	if !UseChecksAPI {
+		return updateStatus(log, ghc, pr, prSuite, report.State)
+	}
+	return updateCheckRun(log, ghc, pr, report)
+}
+
+// updateCheckRun creates or updates the verify-conformance check run of the head commit of the PR
+// with the comment as the summary and an annotation for each located hint
+func updateCheckRun(log *logrus.Entry, ghc githubClient, pr *suite.PullRequestQuery, report *suite.Report) error {
+	org, repo, sha := string(pr.Repository.Owner.Login), string(pr.Repository.Name), string(pr.HeadRefOID)
+	checkRun := newCheckRun(sha, report)
+	if pr.IsDraft {
+		checkRun.Output.Title = draftResultDescription
+	}
+
+	checkRuns, err := ghc.ListCheckRuns(org, repo, sha)
+	if err != nil {
+		log.Infof("PR %v failed to list check runs: %v", pr.Number, err)
+		return err
+	}
+	for _, existing := range checkRuns.CheckRuns {
+		if existing.Name != checkRunName {
+			continue
+		}
+		if existing.Conclusion == checkRun.Conclusion && existing.Output.Summary == checkRun.Output.Summary {
+			log.Infof("PR %v check run unchanged", pr.Number)
+			return nil
+		}
+		log.Infof("PR %v updating check run with conclusion '%v'", pr.Number, checkRun.Conclusion)
+		if err := ghc.UpdateCheckRun(org, repo, existing.ID, checkRun); err != nil {
+			log.Infof("PR %v failed to update check run: %v", pr.Number, err)
+			return err
+		}
+		return nil
+	}
+	log.Infof("PR %v creating check run with conclusion '%v'", pr.Number, checkRun.Conclusion)
+	if _, err := ghc.CreateCheckRun(org, repo, checkRun); err != nil {
+		log.Infof("PR %v failed to create check run: %v", pr.Number, err)
+		return err
+	}
+	return nil
+}
+
+// newCheckRun returns a completed check run for report on the commit sha
+func newCheckRun(sha string, report *suite.Report) github.CheckRun {
+	conclusion, found := checkRunConclusions[report.State]
+	if !found {
+		conclusion = "neutral"
+	}
+	title := "All checks are passing"
+	switch report.State {
+	case "failure":
+		title = "Please check failing requirements and update accordingly"
+	case "pending":
+		title = "Unable to check the submission"
+	}
+	summary := report.Comment
+	if len(summary) > checkRunMaxSummaryLength {
+		summary = strings.ToValidUTF8(summary[:checkRunMaxSummaryLength], "")
+	}
+	annotations := []github.CheckRunAnnotation{}
+	for _, a := range report.Annotations {
+		if len(annotations) == checkRunMaxAnnotations {
+			break
+		}
+		annotations = append(annotations, github.CheckRunAnnotation{
+			Path:            a.Path,
+			StartLine:       a.StartLine,
+			EndLine:         a.EndLine,
+			AnnotationLevel: "failure",
+			Title:           a.Title,
+			Message:         a.Message,
+		})
+	}
+	return github.CheckRun{
+		Name:        checkRunName,
+		HeadSHA:     sha,
+		Status:      "completed",
+		Conclusion:  conclusion,
+		CompletedAt: time.Now().UTC().Format(time.RFC3339),
+		Output: github.CheckRunOutput{
+			Title:       title,
+			Summary:     summary,
+			Annotations: annotations,
+		},
+	}
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"testing"
+
+	"sigs.k8s.io/verify-conformance/internal/suite"
+)
+
+func TestNewCheckRun(t *testing.T) {
+	type testCase struct {
+		Name                     string
+		Report                   *suite.Report
+		ExpectedConclusion       string
+		ExpectedTitle            string
+		ExpectedAnnotationsCount int
+	}
+
+	manyAnnotations := []suite.Annotation{}
+	for i := 0; i < 60; i++ {
+		manyAnnotations = append(manyAnnotations, suite.Annotation{Path: "v1.35/coolkube/PRODUCT.yaml", StartLine: i + 1, EndLine: i + 1})
+	}
+
+	for _, tc := range []testCase{
+		{
+			Name:               "success",
+			Report:             &suite.Report{State: "success", Comment: "All requirements (20) have passed for the submission!"},
+			ExpectedConclusion: "success",
+			ExpectedTitle:      "All checks are passing",
+		},
+		{
+			Name: "failure with annotations",
+			Report: &suite.Report{State: "failure", Comment: "- the PRODUCT.yaml metadata is valid", Annotations: []suite.Annotation{
+				{Path: "v1.35/coolkube/PRODUCT.yaml", StartLine: 3, EndLine: 3, Title: "the PRODUCT.yaml metadata is valid", Message: "missing required field 'vendor' in 'PRODUCT.yaml'"},
+			}},
+			ExpectedConclusion:       "failure",
+			ExpectedTitle:            "Please check failing requirements and update accordingly",
+			ExpectedAnnotationsCount: 1,
+		},
+		{
+			Name:               "pending",
+			Report:             &suite.Report{State: "pending", Comment: "The release version v1.37 is unable to be processed at this time"},
+			ExpectedConclusion: "neutral",
+			ExpectedTitle:      "Unable to check the submission",
+		},
+		{
+			Name:                     "annotations are limited",
+			Report:                   &suite.Report{State: "failure", Annotations: manyAnnotations},
+			ExpectedConclusion:       "failure",
+			ExpectedTitle:            "Please check failing requirements and update accordingly",
+			ExpectedAnnotationsCount: checkRunMaxAnnotations,
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			checkRun := newCheckRun("abc123", tc.Report)
+			if checkRun.Name != checkRunName || checkRun.HeadSHA != "abc123" || checkRun.Status != "completed" {
+				t.Fatalf("error: unexpected check run: %+v", checkRun)
+			}
+			if checkRun.Conclusion != tc.ExpectedConclusion {
+				t.Fatalf("error: unexpected conclusion; want = %v; got = %v", tc.ExpectedConclusion, checkRun.Conclusion)
+			}
+			if checkRun.Output.Title != tc.ExpectedTitle {
+				t.Fatalf("error: unexpected title; want = %v; got = %v", tc.ExpectedTitle, checkRun.Output.Title)
+			}
+			if checkRun.Output.Summary != tc.Report.Comment {
+				t.Fatalf("error: unexpected summary; want = %v; got = %v", tc.Report.Comment, checkRun.Output.Summary)
+			}
+			if len(checkRun.Output.Annotations) != tc.ExpectedAnnotationsCount {
+				t.Fatalf("error: unexpected annotations count; want = %v; got = %v", tc.ExpectedAnnotationsCount, len(checkRun.Output.Annotations))
+			}
+			for _, a := range checkRun.Output.Annotations {
+				if a.AnnotationLevel != "failure" || a.Path == "" || a.StartLine == 0 {
+					t.Fatalf("error: unexpected annotation: %+v", a)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateCheckRun(t *testing.T) {
+	pr := &suite.PullRequestQuery{Number: 1, HeadRefOID: "abc123"}
+	pr.Repository.Name = "k8s-conformance"
+	pr.Repository.Owner.Login = "cncf"
+	ghc := NewFakeGitHubClient([]*prContext{{PullRequestQuery: pr}})
+
+	for _, step := range []struct {
+		Name               string
+		Report             *suite.Report
+		ExpectedConclusion string
+		ExpectedSummary    string
+	}{
+		{
+			Name:               "created",
+			Report:             &suite.Report{State: "failure", Comment: "- all tests pass"},
+			ExpectedConclusion: "failure",
+			ExpectedSummary:    "- all tests pass",
+		},
+		{
+			Name:               "unchanged",
+			Report:             &suite.Report{State: "failure", Comment: "- all tests pass"},
+			ExpectedConclusion: "failure",
+			ExpectedSummary:    "- all tests pass",
+		},
+		{
+			Name:               "updated",
+			Report:             &suite.Report{State: "success", Comment: "All requirements (20) have passed for the submission!"},
+			ExpectedConclusion: "success",
+			ExpectedSummary:    "All requirements (20) have passed for the submission!",
+		},
+	} {
+		if err := updateCheckRun(log, ghc, pr, step.Report); err != nil {
+			t.Fatalf("error: unexpected error when %v: %v", step.Name, err)
+		}
+		checkRuns := ghc.PopulatedPullRequests[0].CheckRuns
+		if len(checkRuns) != 1 {
+			t.Fatalf("error: expected a single check run when %v; got = %v", step.Name, len(checkRuns))
+		}
+		if checkRuns[0].Conclusion != step.ExpectedConclusion || checkRuns[0].Output.Summary != step.ExpectedSummary {
+			t.Fatalf("error: unexpected check run when %v: %+v", step.Name, checkRuns[0])
+		}
+	}
+	if ghc.PopulatedPullRequests[0].Status.State != "" {
+		t.Fatalf("error: expected no commit status; got = %v", ghc.PopulatedPullRequests[0].Status.State)
+	}
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/pluginhelp"
+
+	"sigs.k8s.io/verify-conformance/internal/common"
+	"sigs.k8s.io/verify-conformance/internal/suite"
+)
+
+const (
+	commandRecheck = "recheck"
+	commandExplain = "explain"
+	commandHelp    = "help"
+)
+
+var (
+	commandRegex = regexp.MustCompile(`(?m)^/` + PluginName + `(?:[ \t]+(\S+))?(?:[ \t]+(.*?))?[ \t]*\r?$`)
+
+	commands = []pluginhelp.Command{
+		{
+			Usage:       "/" + PluginName + " " + commandRecheck,
+			Description: "Runs the conformance submission checks against the PR again.",
+			WhoCanUse:   "Anyone",
+			Examples:    []string{"/" + PluginName + " " + commandRecheck},
+		},
+		{
+			Usage:       "/" + PluginName + " " + commandExplain + " <scenario>",
+			Description: "Explains a requirement, replying with the description and steps of the scenario.",
+			WhoCanUse:   "Anyone",
+			Examples:    []string{"/" + PluginName + " " + commandExplain + " all tests pass"},
+		},
+		{
+			Usage:       "/" + PluginName + " " + commandHelp,
+			Description: "Lists the available commands.",
+			WhoCanUse:   "Anyone",
+			Examples:    []string{"/" + PluginName + " " + commandHelp},
+		},
+	}
+)
+
+type command struct {
+	Name string
+	Args string
+}
+
+// parseCommands returns the commands for the plugin found at the start of lines in body
+func parseCommands(body string) (cmds []command) {
+	for _, match := range commandRegex.FindAllStringSubmatch(body, -1) {
+		cmds = append(cmds, command{
+			Name: strings.ToLower(match[1]),
+			Args: strings.TrimSpace(match[2]),
+		})
+	}
+	return cmds
+}
+
+func helpComment() string {
+	lines := []string{"The following commands are available:", ""}
+	for _, c := range commands {
+		lines = append(lines, fmt.Sprintf("- `%v`: %v", c.Usage, c.Description))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func explainComment(scenarioName string) (string, error) {
+	dataFS, release := common.AcquireDataFS()
+	defer release()
+	scenarios, err := suite.GetScenarios(dataFS, []string{suite.FeaturesFolder})
+	if err != nil {
+		return "", err
+	}
+	names := []string{}
+	for _, s := range scenarios {
+		names = append(names, "- "+s.Name)
+	}
+	if scenarioName == "" {
+		return fmt.Sprintf("Please name the scenario to explain, one of:\n\n%v", strings.Join(names, "\n")), nil
+	}
+	found := suite.FindScenarios(scenarios, scenarioName)
+	switch len(found) {
+	case 0:
+		return fmt.Sprintf("Unable to find a scenario named '%v', it must be one of:\n\n%v", scenarioName, strings.Join(names, "\n")), nil
+	case 1:
+		return found[0].Markdown(), nil
+	}
+	names = []string{}
+	for _, s := range found {
+		names = append(names, "- "+s.Name)
+	}
+	return fmt.Sprintf("There are %v scenarios matching '%v', please pick one of:\n\n%v", len(found), scenarioName, strings.Join(names, "\n")), nil
+}
+
+// handleCommand runs a single command from a comment on a PR
+func handleCommand(ghc githubClient, ice *github.IssueCommentEvent, cmd command, recheck func(PRKey) error) error {
+	org, repo, number := ice.Repo.Owner.Login, ice.Repo.Name, ice.Issue.Number
+	var reply string
+	switch cmd.Name {
+	case commandRecheck:
+		return recheck(PRKey{Org: org, Repo: repo, Number: number})
+	case commandExplain:
+		comment, err := explainComment(cmd.Args)
+		if err != nil {
+			return err
+		}
+		reply = comment
+	case commandHelp, "":
+		reply = helpComment()
+	default:
+		reply = fmt.Sprintf("Unknown command '%v'. %v", cmd.Name, helpComment())
+	}
+	return ghc.CreateComment(org, repo, number, fmt.Sprintf("@%v: %v", ice.Comment.User.Login, reply))
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_parseCommands(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []command
+	}{
+		{
+			name: "recheck",
+			body: "/verify-conformance recheck",
+			want: []command{{Name: "recheck"}},
+		},
+		{
+			name: "explain with arguments",
+			body: "/verify-conformance explain  all tests pass \r\n",
+			want: []command{{Name: "explain", Args: "all tests pass"}},
+		},
+		{
+			name: "several commands between text",
+			body: "hello\n/verify-conformance help\nand\n/verify-conformance RECHECK",
+			want: []command{{Name: "help"}, {Name: "recheck"}},
+		},
+		{
+			name: "no subcommand",
+			body: "/verify-conformance",
+			want: []command{{}},
+		},
+		{
+			name: "not at the start of a line",
+			body: "please run /verify-conformance recheck",
+		},
+		{
+			name: "another plugin",
+			body: "/verify-conformances recheck\n/retest",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseCommands(tt.body); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseCommands() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_helpComment(t *testing.T) {
+	comment := helpComment()
+	for _, c := range commands {
+		if !strings.Contains(comment, c.Usage) {
+			t.Fatalf("error: help comment is missing command '%v'", c.Usage)
+		}
+	}
+}
+
+func Test_explainComment(t *testing.T) {
+	tests := []struct {
+		name         string
+		scenarioName string
+		want         string
+	}{
+		{
+			name:         "exact match",
+			scenarioName: "All Tests Pass",
+			want:         "it appears that some tests failed in the product submission",
+		},
+		{
+			name:         "examples",
+			scenarioName: "submission contains all required files",
+			want:         `| "junit_01.xml" | "xml" |`,
+		},
+		{
+			name:         "several matches",
+			scenarioName: "PRODUCT.yaml",
+			want:         "scenarios matching 'PRODUCT.yaml'",
+		},
+		{
+			name:         "no match",
+			scenarioName: "soup",
+			want:         "Unable to find a scenario named 'soup'",
+		},
+		{
+			name: "no scenario",
+			want: "Please name the scenario to explain",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := explainComment(tt.scenarioName)
+			if err != nil {
+				t.Fatalf("explainComment() error = %v", err)
+			}
+			if !strings.Contains(got, tt.want) {
+				t.Errorf("explainComment() = %v, want to contain %v", got, tt.want)
+			}
+		})
+	}
+}
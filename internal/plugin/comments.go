@@ -0,0 +1,254 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/prow/pkg/github"
+
+	"sigs.k8s.io/verify-conformance/internal/suite"
+)
+
+const (
+	// commentMarkerPrefix starts the hidden marker which identifies the comment of the bot,
+	// carrying the report it was written for
+	commentMarkerPrefix = "<!-- verify-conformance-report:"
+	commentMarkerSuffix = " -->"
+	// commandReplyPrefix starts the replies to commands, which are left alone
+	commandReplyPrefix = "@"
+	// commentMaxLength is the most the body of the comment may be, below the 65536 characters GitHub accepts
+	commentMaxLength = 65000
+	// commentTruncatedNotice follows the result when it is too long for the comment
+	commentTruncatedNotice = "\n\n_The result is too long for a comment and has been truncated._"
+)
+
+// commentReport is what the comment of the bot keeps of the report it was written for,
+// to compare the next report against
+type commentReport struct {
+	// Comment is the result, at the start of the body and so not in the marker
+	Comment string `json:"-"`
+	// Hash is the SHA-256 of the whole result, which the body may only have a truncated part of
+	Hash string `json:"hash"`
+	// Length is the length of the result at the start of the body
+	Length int      `json:"length"`
+	Passed []string `json:"passed,omitempty"`
+	Failed []string `json:"failed,omitempty"`
+}
+
+func newCommentReport(report *suite.Report) commentReport {
+	hash := sha256.Sum256([]byte(report.Comment))
+	c := commentReport{
+		Comment: report.Comment,
+		Hash:    hex.EncodeToString(hash[:]),
+		Length:  len(report.Comment),
+	}
+	for _, sc := range report.Scenarios {
+		switch sc.Status {
+		case suite.ScenarioStatusPassed:
+			c.Passed = append(c.Passed, sc.Name)
+		case suite.ScenarioStatusFailed:
+			c.Failed = append(c.Failed, sc.Name)
+		}
+	}
+	return c
+}
+
+// sameResult returns whether c and other are for the same result
+func (c commentReport) sameResult(other commentReport) bool {
+	return c.Hash == other.Hash && slices.Equal(c.Passed, other.Passed) && slices.Equal(c.Failed, other.Failed)
+}
+
+// marker returns the hidden marker for the comment, with the report encoded so that it can't end the HTML comment
+func (c commentReport) marker() (string, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return commentMarkerPrefix + base64.StdEncoding.EncodeToString(data) + commentMarkerSuffix, nil
+}
+
+// parseCommentMarker returns the report in the marker of body, and whether it has one
+func parseCommentMarker(body string) (c commentReport, ok bool) {
+	i := strings.LastIndex(body, commentMarkerPrefix)
+	if i < 0 {
+		return commentReport{}, false
+	}
+	encoded, _, found := strings.Cut(body[i+len(commentMarkerPrefix):], commentMarkerSuffix)
+	if !found {
+		return commentReport{}, false
+	}
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return commentReport{}, false
+	}
+	if err := json.Unmarshal(data, &c); err != nil {
+		return commentReport{}, false
+	}
+	if c.Length < 0 || c.Length > i {
+		return commentReport{}, false
+	}
+	c.Comment = body[:c.Length]
+	return c, true
+}
+
+// changesSince returns the requirements which passed and failed in c, having failed and not failed in previous
+func (c commentReport) changesSince(previous commentReport) (fixed []string, failing []string) {
+	for _, name := range c.Passed {
+		if slices.Contains(previous.Failed, name) {
+			fixed = append(fixed, name)
+		}
+	}
+	for _, name := range c.Failed {
+		if !slices.Contains(previous.Failed, name) {
+			failing = append(failing, name)
+		}
+	}
+	return fixed, failing
+}
+
+// truncateComment returns the start of s in at most length bytes, leaving out a split character
+func truncateComment(s string, length int) string {
+	if length <= 0 {
+		return ""
+	}
+	if len(s) <= length {
+		return s
+	}
+	return strings.ToValidUTF8(s[:length], "")
+}
+
+// renderComment returns the body of the comment for current, followed by the changes since previous
+// and previous itself collapsed, when there is a previous report.
+// The body is kept within commentMaxLength by leaving out the previous result and then truncating the current one.
+func renderComment(current commentReport, previous *commentReport) (string, error) {
+	changes := ""
+	previousResult := ""
+	if previous != nil {
+		// the changes are only known when the suite ran for both reports
+		if len(previous.Passed)+len(previous.Failed) > 0 && len(current.Passed)+len(current.Failed) > 0 {
+			fixed, failing := current.changesSince(*previous)
+			lines := []string{}
+			for _, name := range fixed {
+				lines = append(lines, fmt.Sprintf("- newly fixed: %v", name))
+			}
+			for _, name := range failing {
+				lines = append(lines, fmt.Sprintf("- newly failing: %v", name))
+			}
+			if len(lines) > 0 {
+				changes = "**Changes since the last check**\n\n" + strings.Join(lines, "\n")
+			}
+		}
+		previousResult = fmt.Sprintf("<details>\n<summary>Previous result</summary>\n\n%v\n\n</details>", previous.Comment)
+	}
+
+	// the marker is largest with the whole result, so it fits after any truncation
+	marker, err := current.marker()
+	if err != nil {
+		return "", err
+	}
+	size := func(sections ...string) int {
+		n := len(marker)
+		for _, section := range sections {
+			if section != "" {
+				n += len(section) + len("\n\n")
+			}
+		}
+		return n
+	}
+	comment := current.Comment
+	if size(comment, changes, previousResult) > commentMaxLength {
+		previousResult = ""
+	}
+	if size(comment, changes) > commentMaxLength {
+		comment = truncateComment(comment, commentMaxLength-size(changes)-len(commentTruncatedNotice)-len("\n\n"))
+		current.Length = len(comment)
+		comment += commentTruncatedNotice
+		if marker, err = current.marker(); err != nil {
+			return "", err
+		}
+	}
+
+	sections := []string{}
+	for _, section := range []string{comment, changes, previousResult, marker} {
+		if section != "" {
+			sections = append(sections, section)
+		}
+	}
+	return strings.Join(sections, "\n\n"), nil
+}
+
+// updateComments keeps a single comment of the bot on the PR for the report, editing it in place.
+// Other comments of the bot, such as those from before the comment was edited in place, are removed,
+// except for replies to commands.
+func updateComments(log *logrus.Entry, ghc githubClient, pr *suite.PullRequestQuery, prSuite *suite.PRSuite, report *suite.Report) error {
+	org, repo, number := string(pr.Repository.Owner.Login), string(pr.Repository.Name), int(pr.Number)
+	comments, err := ghc.ListIssueCommentsWithContext(context.TODO(), org, repo, number)
+	if err != nil {
+		return fmt.Errorf("unable to list comments, %v", err)
+	}
+	botUserChecker, err := ghc.BotUserChecker()
+	if err != nil {
+		return fmt.Errorf("unable to get bot name, %v", err)
+	}
+	var sticky *github.IssueComment
+	var previous *commentReport
+	for i := range comments {
+		if !botUserChecker(comments[i].User.Login) {
+			continue
+		}
+		if c, ok := parseCommentMarker(comments[i].Body); ok {
+			sticky, previous = &comments[i], &c
+		}
+	}
+	current := newCommentReport(report)
+	if previous != nil && current.sameResult(*previous) {
+		log.Printf("warning: nothing new to add in PR (%v)\n", number)
+		return nil
+	}
+
+	body, err := renderComment(current, previous)
+	if err != nil {
+		return err
+	}
+	stickyID := 0
+	if sticky == nil {
+		err = ghc.CreateComment(org, repo, number, body)
+	} else {
+		stickyID = sticky.ID
+		err = ghc.EditComment(org, repo, stickyID, body)
+	}
+	if err != nil {
+		return err
+	}
+
+	err = ghc.DeleteStaleComments(org, repo, number, comments, func(ic github.IssueComment) bool {
+		return botUserChecker(ic.User.Login) && ic.ID != stickyID && ic.Body != "" && !strings.HasPrefix(ic.Body, commandReplyPrefix)
+	})
+	if err != nil {
+		return fmt.Errorf("unable to prune stale comments comments on PR (%v), %v", number, err)
+	}
+	return nil
+}
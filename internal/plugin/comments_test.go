@@ -0,0 +1,302 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/prow/pkg/github"
+
+	"sigs.k8s.io/verify-conformance/internal/suite"
+)
+
+func TestParseCommentMarker(t *testing.T) {
+	prSuite := suite.NewPRSuite(&suite.PullRequest{})
+	r := prSuite.NewReport("- the README.md contains instructions -->", []string{}, "failure")
+	r.Scenarios = []suite.ScenarioResult{
+		{Name: "PR title is not empty", Status: suite.ScenarioStatusPassed},
+		{Name: "the README.md contains instructions to reproduce the results", Status: suite.ScenarioStatusFailed},
+	}
+	report := newCommentReport(r)
+	body, err := renderComment(report, nil)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	for _, tc := range []struct {
+		Name           string
+		Body           string
+		ExpectedReport commentReport
+		ExpectedOK     bool
+	}{
+		{
+			Name:           "rendered comment",
+			Body:           body,
+			ExpectedReport: report,
+			ExpectedOK:     true,
+		},
+		{
+			Name: "comment from before the marker",
+			Body: "All requirements (20) have passed for the submission!",
+		},
+		{
+			Name: "invalid marker",
+			Body: commentMarkerPrefix + "not base64!" + commentMarkerSuffix,
+		},
+		{
+			Name: "marker with a length past the marker",
+			Body: "short" + func() string {
+				m, _ := report.marker()
+				return m
+			}(),
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			got, ok := parseCommentMarker(tc.Body)
+			if ok != tc.ExpectedOK || !reflect.DeepEqual(got, tc.ExpectedReport) {
+				t.Fatalf("error: unexpected report; want = %+v (%v); got = %+v (%v)", tc.ExpectedReport, tc.ExpectedOK, got, ok)
+			}
+		})
+	}
+}
+
+func TestRenderComment(t *testing.T) {
+	type testCase struct {
+		Name                string
+		Current             commentReport
+		Previous            *commentReport
+		ExpectedContains    []string
+		ExpectedNotContains []string
+	}
+
+	for _, tc := range []testCase{
+		{
+			Name:                "first check",
+			Current:             commentReport{Comment: "the comment", Failed: []string{"all tests pass"}},
+			ExpectedContains:    []string{"the comment", commentMarkerPrefix},
+			ExpectedNotContains: []string{"Previous result", "Changes since the last check"},
+		},
+		{
+			Name:     "changes since the previous check",
+			Current:  commentReport{Comment: "the new comment", Passed: []string{"all tests pass"}, Failed: []string{"there is only one commit"}},
+			Previous: &commentReport{Comment: "the old comment", Passed: []string{"there is only one commit"}, Failed: []string{"all tests pass"}},
+			ExpectedContains: []string{
+				"the new comment",
+				"**Changes since the last check**\n\n- newly fixed: all tests pass\n- newly failing: there is only one commit",
+				"<details>\n<summary>Previous result</summary>\n\nthe old comment\n\n</details>",
+			},
+		},
+		{
+			Name:                "previous check without a suite run",
+			Current:             commentReport{Comment: "the new comment", Failed: []string{"all tests pass"}},
+			Previous:            &commentReport{Comment: "The release version v1.37 is unable to be processed at this time"},
+			ExpectedContains:    []string{"Previous result", "The release version v1.37 is unable to be processed at this time"},
+			ExpectedNotContains: []string{"Changes since the last check"},
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			body, err := renderComment(tc.Current, tc.Previous)
+			if err != nil {
+				t.Fatalf("error: %v", err)
+			}
+			if !strings.HasPrefix(body, tc.Current.Comment) {
+				t.Fatalf("error: expected the comment to start with the current result; got = %v", body)
+			}
+			for _, s := range tc.ExpectedContains {
+				if !strings.Contains(body, s) {
+					t.Fatalf("error: expected the comment to contain '%v'; got = %v", s, body)
+				}
+			}
+			for _, s := range tc.ExpectedNotContains {
+				if strings.Contains(body, s) {
+					t.Fatalf("error: expected the comment not to contain '%v'; got = %v", s, body)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderCommentMaxLength(t *testing.T) {
+	prSuite := suite.NewPRSuite(&suite.PullRequest{})
+	prSuite.KubernetesReleaseVersion = "v1.35"
+	requiredTests, err := prSuite.GetRequiredTests()
+	if err != nil {
+		t.Fatalf("error: unable to get the required tests: %v", err)
+	}
+	missingTests := []string{}
+	for test := range requiredTests {
+		missingTests = append(missingTests, test)
+	}
+	sort.Strings(missingTests)
+	newReport := func(passed int) *suite.Report {
+		comment, err := prSuite.RenderComment(suite.CommentTemplateResults, &suite.CommentData{
+			ReleaseVersion: "v1.35",
+			Requirements:   20,
+			Passed:         passed,
+			Failures: []suite.ResultPrepare{{
+				Name:  "all required tests are present",
+				Hints: []string{"the following test(s) are missing or failed: \n    - " + strings.Join(missingTests, "\n    - ")},
+			}},
+			MissingTests: missingTests,
+		})
+		if err != nil {
+			t.Fatalf("error: unable to render the comment: %v", err)
+		}
+		r := prSuite.NewReport(comment, []string{}, "failure")
+		r.Scenarios = []suite.ScenarioResult{{Name: "all required tests are present", Status: suite.ScenarioStatusFailed}}
+		return r
+	}
+	previous := newCommentReport(newReport(19))
+	current := newCommentReport(newReport(18))
+	if len(previous.Comment)+len(current.Comment) < commentMaxLength {
+		t.Fatalf("error: expected the results to be longer than a comment together; got = %v", len(previous.Comment)+len(current.Comment))
+	}
+
+	for _, tc := range []struct {
+		Name                string
+		Current             commentReport
+		Previous            *commentReport
+		ExpectedContains    []string
+		ExpectedNotContains []string
+	}{
+		{
+			Name:                "previous result left out",
+			Current:             current,
+			Previous:            &previous,
+			ExpectedContains:    []string{current.Comment},
+			ExpectedNotContains: []string{"Previous result", commentTruncatedNotice},
+		},
+		{
+			Name: "result truncated",
+			Current: func() commentReport {
+				c := current
+				c.Comment = strings.Repeat(current.Comment, 3)
+				return c
+			}(),
+			Previous:            &previous,
+			ExpectedContains:    []string{commentTruncatedNotice},
+			ExpectedNotContains: []string{"Previous result"},
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			body, err := renderComment(tc.Current, tc.Previous)
+			if err != nil {
+				t.Fatalf("error: %v", err)
+			}
+			if len(body) > commentMaxLength {
+				t.Fatalf("error: expected the comment to be at most %v long; got = %v", commentMaxLength, len(body))
+			}
+			for _, s := range tc.ExpectedContains {
+				if !strings.Contains(body, s) {
+					t.Fatalf("error: expected the comment to contain '%v'", s)
+				}
+			}
+			for _, s := range tc.ExpectedNotContains {
+				if strings.Contains(body, s) {
+					t.Fatalf("error: expected the comment not to contain '%v'", s)
+				}
+			}
+			parsed, ok := parseCommentMarker(body)
+			if !ok {
+				t.Fatalf("error: expected the comment to have a marker")
+			}
+			if !parsed.sameResult(tc.Current) {
+				t.Fatalf("error: expected the marker to be for the current result")
+			}
+			if !strings.HasPrefix(tc.Current.Comment, parsed.Comment) || parsed.Comment == "" {
+				t.Fatalf("error: expected the marker to give the start of the current result")
+			}
+		})
+	}
+}
+
+func TestUpdateComments(t *testing.T) {
+	pr := &suite.PullRequestQuery{Number: 0}
+	pr.Repository.Name = "k8s-conformance"
+	pr.Repository.Owner.Login = "cncf"
+	ghc := NewFakeGitHubClient([]*prContext{{
+		PullRequestQuery: pr,
+		Comments: []github.IssueComment{
+			{ID: 100, Body: "a comment from before the marker", User: github.User{Login: "cncfci(bot)"}},
+			{ID: 101, Body: "@submitter: a reply to a command", User: github.User{Login: "cncfci(bot)"}},
+			{ID: 102, Body: "/verify-conformance recheck", User: github.User{Login: "submitter"}},
+		},
+	}})
+	prSuite := suite.NewPRSuite(&suite.PullRequest{PullRequestQuery: *pr})
+
+	failing := prSuite.NewReport("- all tests pass", []string{}, "failure")
+	failing.Scenarios = []suite.ScenarioResult{{Name: "all tests pass", Status: suite.ScenarioStatusFailed}}
+	passing := prSuite.NewReport("All requirements (20) have passed for the submission!", []string{}, "success")
+	passing.Scenarios = []suite.ScenarioResult{{Name: "all tests pass", Status: suite.ScenarioStatusPassed}}
+
+	for _, step := range []struct {
+		Name                string
+		Report              *suite.Report
+		ExpectedIDs         []int
+		ExpectedContains    []string
+		ExpectedNotContains []string
+	}{
+		{
+			Name:                "created, removing the comment from before the marker",
+			Report:              failing,
+			ExpectedIDs:         []int{101, 102, 4},
+			ExpectedContains:    []string{"- all tests pass"},
+			ExpectedNotContains: []string{"Previous result"},
+		},
+		{
+			Name:                "unchanged",
+			Report:              failing,
+			ExpectedIDs:         []int{101, 102, 4},
+			ExpectedNotContains: []string{"Previous result"},
+		},
+		{
+			Name:        "edited in place",
+			Report:      passing,
+			ExpectedIDs: []int{101, 102, 4},
+			ExpectedContains: []string{
+				"All requirements (20) have passed for the submission!",
+				"- newly fixed: all tests pass",
+				"<summary>Previous result</summary>\n\n- all tests pass",
+			},
+		},
+	} {
+		if err := updateComments(log, ghc, pr, prSuite, step.Report); err != nil {
+			t.Fatalf("error: unexpected error when %v: %v", step.Name, err)
+		}
+		comments := ghc.PopulatedPullRequests[0].Comments
+		ids := []int{}
+		for _, c := range comments {
+			ids = append(ids, c.ID)
+		}
+		if !reflect.DeepEqual(ids, step.ExpectedIDs) {
+			t.Fatalf("error: unexpected comments when %v; want = %v; got = %v", step.Name, step.ExpectedIDs, ids)
+		}
+		body := comments[len(comments)-1].Body
+		for _, s := range step.ExpectedContains {
+			if !strings.Contains(body, s) {
+				t.Fatalf("error: expected the comment to contain '%v' when %v; got = %v", s, step.Name, body)
+			}
+		}
+		for _, s := range step.ExpectedNotContains {
+			if strings.Contains(body, s) {
+				t.Fatalf("error: expected the comment not to contain '%v' when %v; got = %v", s, step.Name, body)
+			}
+		}
+	}
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/prow/pkg/plugins"
+)
+
+const (
+	// resyncJitterFactor is the maximum fraction of the period added to each wait between sweeps
+	resyncJitterFactor = 0.1
+)
+
+// SweepSummary describes the outcome of a single run over all open PRs
+type SweepSummary struct {
+	Considered int
+	Succeeded  int
+	Failed     int
+	Duration   time.Duration
+}
+
+// Resync periodically re-runs HandleAll so that results stay current after
+// the conformance metadata or stable.txt change.
+type Resync struct {
+	log    *logrus.Entry
+	period time.Duration
+	sweep  func() (SweepSummary, error)
+
+	running sync.Mutex
+	wg      sync.WaitGroup
+
+	mu sync.Mutex
+	// stopped is set once Run returns, after which sweeps are no longer started
+	stopped bool
+}
+
+// NewResync returns a Resync sweeping all PRs configured for the plugin through q every period.
+func NewResync(log *logrus.Entry, ghc githubClient, q *Queue, config *plugins.Configuration, period time.Duration) *Resync {
+	return &Resync{
+		log:    log,
+		period: period,
+		sweep: func() (SweepSummary, error) {
+			return handleAll(log, ghc, config, q)
+		},
+	}
+}
+
+// Run sweeps immediately and then once per jittered period until ctx is done,
+// waiting for an in-flight sweep before returning.
+func (r *Resync) Run(ctx context.Context) {
+	defer r.stop()
+	for {
+		r.Trigger()
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(jitter(r.period, resyncJitterFactor)):
+		}
+	}
+}
+
+// Trigger starts a sweep in the background, unless the previous sweep is still going.
+// It returns whether a sweep was started.
+func (r *Resync) Trigger() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.stopped {
+		r.log.Warn("Skipping periodic update of all PRs, as it is shutting down.")
+		return false
+	}
+	if !r.running.TryLock() {
+		r.log.Warn("Skipping periodic update of all PRs, as the previous one is still running.")
+		return false
+	}
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		defer r.running.Unlock()
+		summary, err := r.sweep()
+		l := r.log.WithFields(logrus.Fields{
+			"considered": summary.Considered,
+			"succeeded":  summary.Succeeded,
+			"failed":     summary.Failed,
+			"duration":   summary.Duration.String(),
+		})
+		if err != nil {
+			l.WithError(err).Error("Error during periodic update of all PRs.")
+			return
+		}
+		l.Info("Finished periodic update of all PRs.")
+	}()
+	return true
+}
+
+// stop stops sweeps from being started and waits for the one in flight
+func (r *Resync) stop() {
+	r.mu.Lock()
+	r.stopped = true
+	r.mu.Unlock()
+	r.wg.Wait()
+}
+
+func jitter(period time.Duration, maxFactor float64) time.Duration {
+	return period + time.Duration(rand.Float64()*maxFactor*float64(period))
+}
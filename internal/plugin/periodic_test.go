@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/prow/pkg/plugins"
+)
+
+func TestResyncTrigger(t *testing.T) {
+	release := make(chan struct{})
+	var sweeps atomic.Int32
+	r := &Resync{
+		log:    log,
+		period: time.Hour,
+		sweep: func() (SweepSummary, error) {
+			sweeps.Add(1)
+			<-release
+			return SweepSummary{Considered: 1, Succeeded: 1}, nil
+		},
+	}
+	if !r.Trigger() {
+		t.Fatalf("error: expected first sweep to start")
+	}
+	if r.Trigger() {
+		t.Fatalf("error: expected sweep to be skipped while the previous one is running")
+	}
+	close(release)
+	r.wg.Wait()
+	if !r.Trigger() {
+		t.Fatalf("error: expected sweep to start after the previous one finished")
+	}
+	r.wg.Wait()
+	if got := sweeps.Load(); got != 2 {
+		t.Fatalf("error: unexpected sweep count: want = 2; got = %v", got)
+	}
+}
+
+func TestResyncRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var sweeps atomic.Int32
+	r := &Resync{
+		log:    log,
+		period: time.Millisecond,
+		sweep: func() (SweepSummary, error) {
+			if sweeps.Add(1) >= 3 {
+				cancel()
+			}
+			return SweepSummary{}, fmt.Errorf("failed")
+		},
+	}
+	done := make(chan struct{})
+	go func() {
+		r.Run(ctx)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatalf("error: Run did not return after its context was cancelled")
+	}
+	if got := sweeps.Load(); got < 3 {
+		t.Fatalf("error: expected at least 3 sweeps; got = %v", got)
+	}
+	// such as when the metadata is switched while shutting down
+	if r.Trigger() {
+		t.Fatalf("error: expected no sweep to start once Run has returned")
+	}
+}
+
+func TestNewResync(t *testing.T) {
+	r := NewResync(log, NewFakeGitHubClient(nil), NewQueue(log, NewFakeGitHubClient(nil), 1), &plugins.Configuration{}, time.Hour)
+	summary, err := r.sweep()
+	if err != nil {
+		t.Fatalf("error: unexpected error: %v", err)
+	}
+	if summary.Considered != 0 {
+		t.Fatalf("error: expected no PRs to be considered without configured repos; got = %v", summary.Considered)
+	}
+}
+
+func Test_jitter(t *testing.T) {
+	period := time.Minute
+	for range 100 {
+		if got := jitter(period, 0.1); got < period || got > period+6*time.Second {
+			t.Fatalf("error: jittered period %v is out of range", got)
+		}
+	}
+}
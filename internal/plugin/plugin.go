@@ -19,14 +19,15 @@ package plugin
 import (
 	"bytes"
 	"context"
-	"crypto/tls"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"net/http"
-	"net/url"
-	"os"
 	"path"
 	"strings"
+	"sync"
 	"time"
 
 	githubql "github.com/shurcooL/githubv4"
@@ -35,9 +36,9 @@ import (
 	"sigs.k8s.io/prow/pkg/github"
 	"sigs.k8s.io/prow/pkg/pluginhelp"
 	"sigs.k8s.io/prow/pkg/plugins"
-	"sigs.k8s.io/yaml"
 
 	"sigs.k8s.io/verify-conformance/internal/common"
+	"sigs.k8s.io/verify-conformance/internal/resolver"
 	"sigs.k8s.io/verify-conformance/internal/suite"
 )
 
@@ -46,6 +47,8 @@ const (
 )
 
 var (
+	// productYAMLURLResolver resolves the URLs in PRODUCT.yaml, replaced in tests to reach a local server
+	productYAMLURLResolver            = resolver.New(resolver.Options{})
 	productYAMLRequiredFieldDateTypes = []ProductYAMLField{
 		{Field: "website_url"},
 		{Field: "repo_url"},
@@ -66,8 +69,6 @@ var (
 		"tests-verified-%v",
 	}
 	managedPRLabelTemplatesWithFileName = []string{"missing-file-%v"}
-	// TODO swap out for ldflag to override variable if built with Ko
-	godogPaths = []string{"./features/", "./kodata/features/", "/var/run/ko/features/", "../../kodata/features/"}
 )
 
 type ProductYAMLField struct {
@@ -79,6 +80,7 @@ type githubClient interface {
 	GetCombinedStatus(org, repo, ref string) (*github.CombinedStatus, error)
 	GetIssueLabels(org, repo string, number int) ([]github.Label, error)
 	CreateComment(org, repo string, number int, comment string) error
+	EditComment(org, repo string, id int, comment string) error
 	ListIssueCommentsWithContext(ctx context.Context, org, repo string, number int) ([]github.IssueComment, error)
 	BotUserChecker() (func(candidate string) bool, error)
 	AddLabel(org, repo string, number int, label string) error
@@ -87,6 +89,11 @@ type githubClient interface {
 	QueryWithGitHubAppsSupport(context.Context, interface{}, map[string]interface{}, string) error
 	GetPullRequest(org, repo string, number int) (*github.PullRequest, error)
 	GetPullRequestChanges(org, repo string, number int) ([]github.PullRequestChange, error)
+	GetDirectory(org, repo, dirpath, commit string) ([]github.DirectoryContent, error)
+	GetFile(org, repo, filepath, commit string) ([]byte, error)
+	ListCheckRuns(org, repo, ref string) (*github.CheckRunList, error)
+	CreateCheckRun(org, repo string, checkRun github.CheckRun) (int64, error)
+	UpdateCheckRun(org, repo string, checkRunId int64, checkRun github.CheckRun) error
 }
 
 type PullRequest struct {
@@ -112,6 +119,7 @@ type PullRequest struct {
 		}
 	} `graphql:"files(first:10)"`
 	Title   githubql.String
+	IsDraft githubql.Boolean
 	Commits struct {
 		Nodes []struct {
 			Commit struct {
@@ -154,11 +162,22 @@ type SearchQuery struct {
 	} `graphql:"search(type: ISSUE, first: 100, after: $searchCursor, query: $query)"`
 }
 
+// errUnableToProcess is returned once a PR has been told it can't be processed, so checking it again won't help
+var errUnableToProcess = errors.New("unable to process release file")
+
+// PullRequestByNumberQuery fetches a single PR from its base repo with the same fields as each PR found by SearchQuery
+type PullRequestByNumberQuery struct {
+	Repository struct {
+		PullRequest suite.PullRequestQuery `graphql:"pullRequest(number: $number)"`
+	} `graphql:"repository(owner: $owner, name: $name)"`
+}
+
 // HelpProvider constructs the PluginHelp for this plugin that takes into account enabled repositories.
 // HelpProvider defines the type for the function that constructs the PluginHelp for plugins.
 func HelpProvider(_ []config.OrgRepo) (*pluginhelp.PluginHelp, error) {
 	return &pluginhelp.PluginHelp{
 			Description: `The Verify Conformance Request plugin checks the content of PRs that request Conformance Certification for Kubernetes to see if they are internally consistent. So, for example, if the title of the PR contains a reference to a Kubernetes version then this plugin checks to see that the Sonobouy e2e test logs refer to the same version.`,
+			Commands:    commands,
 		},
 		nil
 }
@@ -219,8 +238,26 @@ func search(ctx context.Context, log *logrus.Entry, ghc githubClient, q string,
 	return ret, nil
 }
 
-func NewPRSuiteForPR(log *logrus.Entry, ghc githubClient, pr *suite.PullRequestQuery) (prSuite *suite.PRSuite, err error) {
+// getPullRequestQuery fetches a PR from the base repo org/repo, so that PRs from forks are checked
+// against the base repo with the commits, files and head SHA as they are found when searching
+func getPullRequestQuery(ctx context.Context, ghc githubClient, org string, repo string, number int) (*suite.PullRequestQuery, error) {
+	q := PullRequestByNumberQuery{}
+	vars := map[string]interface{}{
+		"owner":  githubql.String(org),
+		"name":   githubql.String(repo),
+		"number": githubql.Int(number),
+	}
+	if err := ghc.QueryWithGitHubAppsSupport(ctx, &q, vars, org); err != nil {
+		return nil, fmt.Errorf("error fetching PR (%v) in %v/%v, %v", number, org, repo, err)
+	}
+	pr := q.Repository.PullRequest
+	return &pr, nil
+}
+
+// NewPRSuiteForPR returns the suite for pr, checked against the data in dataFS
+func NewPRSuiteForPR(log *logrus.Entry, ghc githubClient, pr *suite.PullRequestQuery, dataFS fs.FS) (prSuite *suite.PRSuite, err error) {
 	prSuite = suite.NewPRSuite(&suite.PullRequest{PullRequestQuery: *pr})
+	prSuite.DataFS = dataFS
 	issueLabels, err := ghc.GetIssueLabels(string(pr.Repository.Owner.Login), string(pr.Repository.Name), int(pr.Number))
 	if err != nil {
 		return &suite.PRSuite{}, fmt.Errorf("error fetching PR issue labels for issue (%v), %v ", pr.Number, err)
@@ -229,11 +266,16 @@ func NewPRSuiteForPR(log *logrus.Entry, ghc githubClient, pr *suite.PullRequestQ
 		prSuite.PR.Labels = append(prSuite.PR.Labels, l.Name)
 	}
 
-	stableTxt, err := common.GetStableTxt()
+	stableTxt, err := common.ReadStableTxt(prSuite.DataFS)
 	if err != nil {
 		return &suite.PRSuite{}, fmt.Errorf("unable to read latest version info")
 	}
 	prSuite.KubernetesReleaseVersionLatest = stableTxt
+	releaseLifecycle, err := suite.GetReleaseLifecycle(prSuite.DataFS)
+	if err != nil {
+		return &suite.PRSuite{}, fmt.Errorf("unable to read the release lifecycle, %v", err)
+	}
+	prSuite.ReleaseLifecycle = releaseLifecycle
 
 	var productYAMLContent string
 	changes, err := ghc.GetPullRequestChanges(string(pr.Repository.Owner.Login), string(pr.Repository.Name), int(pr.Number))
@@ -263,62 +305,180 @@ func NewPRSuiteForPR(log *logrus.Entry, ghc githubClient, pr *suite.PullRequestQ
 		log.Printf("failed to find PRODUCT.yaml from the list of files in the PR (%v)", pr.Number)
 		return prSuite, nil
 	}
+	ResolveProductYAMLURLDataTypes(log, prSuite, productYAMLContent)
+	return prSuite, nil
+}
 
-	productYAML := map[string]string{}
-	err = yaml.Unmarshal([]byte(productYAMLContent), &productYAML)
+// ResolveProductYAMLURLDataTypes sets the content types which the URL fields of the PRODUCT.yaml content resolve to
+func ResolveProductYAMLURLDataTypes(log *logrus.Entry, prSuite *suite.PRSuite, productYAMLContent string) {
+	pr := prSuite.PR
+	productYAML, err := suite.ParseProductYAML([]byte(productYAMLContent))
 	if err != nil {
 		log.Printf("failed to parse content of PRODUCT.yaml in PR (%v), %v", pr.Number, err)
-		return prSuite, nil
+		return
 	}
 
+	fields := productYAML.Fields()
+	var wg sync.WaitGroup
+	var mu sync.Mutex
 	for _, f := range productYAMLRequiredFieldDateTypes {
-		uri := productYAML[f.Field]
+		uri := fields[f.Field]
 		if uri == "" {
 			log.Printf("field '%v' is empty in PRODUCT.yaml, not resolving URL\n", f.Field)
 			continue
 		}
 		if prSuite.PR.ProductYAMLURLDataTypes == nil {
 			prSuite.PR.ProductYAMLURLDataTypes = map[string]string{}
-		}
-		prSuite.PR.ProductYAMLURLDataTypes[f.Field] = ""
-		u, err := url.ParseRequestURI(uri)
-		if err != nil {
-			log.Printf("failed to parse url '%v' of the field '%v' in PRODUCT.yaml in PR (%v) as it is not a valid URL, %v", uri, f.Field, pr.Number, err)
-			continue
-		}
-		if u.Scheme == "" {
-			u.Scheme = "https"
-		}
-		req, err := http.NewRequest(http.MethodHead, u.String(), nil)
-		if err != nil {
-			log.Printf("failed to prepare new request for URL (%v) for PR (%v), %v", u, pr.Number, err)
-			continue
-		}
-		tr := &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-		}
-		client := &http.Client{Transport: tr}
-		resp, err := client.Do(req)
-		if err != nil {
-			log.Printf("failed to make a HEAD request to url '%v' from the field '%v' in PRODUCT.yaml in PR (%v), %v", u, f.Field, pr.Number, err)
-			continue
-		}
-		contentType := resp.Header.Get("Content-Type")
-		log.Printf("%v: '%v' -> %v = '%v'\n", pr.Number, f.Field, u.String(), contentType)
-		prSuite.PR.ProductYAMLURLDataTypes[f.Field] = contentType
+			prSuite.PR.ProductYAMLURLResults = map[string]*resolver.Result{}
+		}
+		wg.Add(1)
+		go func(field, uri string) {
+			defer wg.Done()
+			result := productYAMLURLResolver.Resolve(context.TODO(), uri)
+			log.Printf("%v: '%v' -> %v %v = '%v' (redirects: %v, insecure: '%v', error: '%v')\n", pr.Number, field, result.Method, uri, result.ContentType, result.Redirects, result.Insecure, result.Error)
+			mu.Lock()
+			defer mu.Unlock()
+			prSuite.PR.ProductYAMLURLDataTypes[field] = result.ContentType
+			prSuite.PR.ProductYAMLURLResults[field] = result
+		}(f.Field, uri)
+	}
+	if productYAML.ProductLogoURL != "" {
+		prSuite.PR.ProductLogo = FetchProductLogo(productYAML.ProductLogoURL)
+		log.Printf("%v: 'product_logo_url' -> %v = '%v' (%v bytes) %v\n", pr.Number, productYAML.ProductLogoURL, prSuite.PR.ProductLogo.ContentType, len(prSuite.PR.ProductLogo.Contents), prSuite.PR.ProductLogo.Error)
+	}
+	wg.Wait()
+}
+
+// FetchProductLogo downloads the product logo at uri, up to suite.MaxProductLogoSize bytes
+func FetchProductLogo(uri string) *suite.ProductLogo {
+	result := productYAMLURLResolver.Fetch(context.TODO(), uri, suite.MaxProductLogoSize)
+	logo := &suite.ProductLogo{URL: uri, ContentType: result.ContentType, Contents: result.Contents, Error: result.Error}
+	if logo.Error == "" && result.Insecure != "" {
+		logo.Error = fmt.Sprintf("it is served with a TLS certificate which is not valid (%v)", result.Insecure)
+	}
+	if logo.Error != "" {
+		logo.Contents = nil
 	}
+	return logo
+}
 
-	return prSuite, nil
+// existingSubmissionsCacheSize is the number of base commits of which the existing submissions are kept
+const existingSubmissionsCacheSize = 16
+
+// existingSubmissionsCache keeps what is read of the existing submissions by base commit,
+// which doesn't change, so that checks and sweeps against the same base don't read it again
+var existingSubmissionsCache = &submissionsCache{}
+
+type submissionsCache struct {
+	mu sync.Mutex
+	// refs are the cached base commits, oldest first
+	refs    []string
+	entries map[string]*submissionsCacheEntry
 }
 
-func GetGodogPaths() (paths []string) {
-	for _, p := range godogPaths {
-		if _, err := os.Stat(p); os.IsNotExist(err) {
+type submissionsCacheEntry struct {
+	// folders are the contents of each release folder
+	folders map[string][]github.DirectoryContent
+	// productYAMLs are the contents of each PRODUCT.yaml read, being empty when missing
+	productYAMLs map[string][]byte
+}
+
+// entry returns the cache entry for ref, adding it and forgetting the oldest base commit once there are too many
+func (c *submissionsCache) entry(ref string) *submissionsCacheEntry {
+	if c.entries == nil {
+		c.entries = map[string]*submissionsCacheEntry{}
+	}
+	if e, found := c.entries[ref]; found {
+		return e
+	}
+	e := &submissionsCacheEntry{folders: map[string][]github.DirectoryContent{}, productYAMLs: map[string][]byte{}}
+	c.entries[ref] = e
+	c.refs = append(c.refs, ref)
+	if len(c.refs) > existingSubmissionsCacheSize {
+		delete(c.entries, c.refs[0])
+		c.refs = c.refs[1:]
+	}
+	return e
+}
+
+// folders returns the cached contents of the release folder on ref, and whether they are cached
+func (c *submissionsCache) folders(ref, release string) ([]github.DirectoryContent, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	contents, found := c.entry(ref).folders[release]
+	return contents, found
+}
+
+func (c *submissionsCache) setFolders(ref, release string, contents []github.DirectoryContent) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entry(ref).folders[release] = contents
+}
+
+// productYAML returns the cached contents of the PRODUCT.yaml at name on ref, and whether they are cached
+func (c *submissionsCache) productYAML(ref, name string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	contents, found := c.entry(ref).productYAMLs[name]
+	return contents, found
+}
+
+func (c *submissionsCache) setProductYAML(ref, name string, contents []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entry(ref).productYAMLs[name] = contents
+}
+
+// GetExistingSubmissions returns the submissions for release on the base commit ref of org/repo,
+// being empty when there are none. The release folder is listed in one request and the PRODUCT.yaml of every submission
+// is read, so that a product under a different folder name is found too; both are cached by ref.
+func GetExistingSubmissions(ghc githubClient, org, repo, ref, release string) ([]*suite.ExistingSubmission, error) {
+	existing := []*suite.ExistingSubmission{}
+	if release == "" {
+		return existing, nil
+	}
+	c := existingSubmissionsCache
+	// without a base commit, the base branch may have moved since anything was cached
+	cached := ref != ""
+	var notFound *github.FileNotFound
+	var contents []github.DirectoryContent
+	found := false
+	if cached {
+		contents, found = c.folders(ref, release)
+	}
+	if !found {
+		var err error
+		contents, err = ghc.GetDirectory(org, repo, release, ref)
+		if err != nil && !errors.As(err, &notFound) {
+			return nil, fmt.Errorf("unable to list '%v' on '%v', %v", release, ref, err)
+		}
+		if cached {
+			c.setFolders(ref, release, contents)
+		}
+	}
+	for _, content := range contents {
+		if content.Type != "dir" {
 			continue
 		}
-		paths = append(paths, p)
+		name := path.Join(content.Path, "PRODUCT.yaml")
+		var productYAML []byte
+		found := false
+		if cached {
+			productYAML, found = c.productYAML(ref, name)
+		}
+		if !found {
+			var err error
+			productYAML, err = ghc.GetFile(org, repo, name, ref)
+			if err != nil && !errors.As(err, &notFound) {
+				return nil, fmt.Errorf("unable to read the PRODUCT.yaml of '%v' on '%v', %v", content.Path, ref, err)
+			}
+			if cached {
+				c.setProductYAML(ref, name, productYAML)
+			}
+		}
+		existing = append(existing, suite.NewExistingSubmission(content.Path, productYAML))
 	}
-	return paths
+	return existing, nil
 }
 
 func labelIsManaged(input string) bool {
@@ -330,6 +490,11 @@ func labelIsManaged(input string) bool {
 	return false
 }
 
+// labelIsManagedByPlugin returns whether the label is one that the plugin sets on PRs
+func labelIsManagedByPlugin(label string) bool {
+	return labelIsManaged(label) || labelIsVersionLabel(label, "") || labelIsFileLabel(label, nil)
+}
+
 func labelIsVersionLabel(label, version string) bool {
 	for _, ml := range managedPRLabelTemplatesWithVersion {
 		if strings.Contains(label, strings.ReplaceAll(ml, "%v", "")) {
@@ -412,55 +577,6 @@ prLabels:
 	return newLabels, removedLabels, nil
 }
 
-func updateComments(log *logrus.Entry, ghc githubClient, pr *suite.PullRequestQuery, prSuite *suite.PRSuite, comment string) error {
-	comments, err := githubClient.ListIssueCommentsWithContext(ghc, context.TODO(), string(pr.Repository.Owner.Login), string(pr.Repository.Name), int(pr.Number))
-	if err != nil {
-		return fmt.Errorf("unable to list comments, %v", err)
-	}
-	botUserChecker, err := githubClient.BotUserChecker(ghc)
-	if err != nil {
-		return fmt.Errorf("unable to get bot name, %v", err)
-	}
-	botComments := []github.IssueComment{}
-	for _, c := range comments {
-		if !botUserChecker(c.User.Login) {
-			continue
-		}
-		if c.Body == "" {
-			continue
-		}
-		botComments = append(botComments, c)
-	}
-	if len(botComments) > 0 && botComments[len(botComments)-1].Body == comment {
-		log.Printf("warning: nothing new to add in PR (%v)\n", int(pr.Number))
-		return nil
-	}
-	botCommentsToPrune := botComments
-	if len(botComments) > 0 {
-		botCommentsToPrune = botComments[:len(botComments)-1]
-	}
-
-	err = githubClient.DeleteStaleComments(
-		ghc,
-		string(pr.Repository.Owner.Login),
-		string(pr.Repository.Name),
-		int(pr.Number),
-		botCommentsToPrune,
-		func(ic github.IssueComment) bool {
-			return botUserChecker(ic.User.Login)
-		},
-	)
-	if err != nil {
-		return fmt.Errorf("unable to prune stale comments comments on PR (%v), %v", int(pr.Number), err)
-	}
-
-	err = githubClient.CreateComment(ghc, string(pr.Repository.Owner.Login), string(pr.Repository.Name), int(pr.Number), comment)
-	if err != nil {
-		return err
-	}
-	return nil
-}
-
 func removeSliceOfStringsFromStringSlice(originalSlice []string, removeSlice []string) (output []string) {
 o:
 	for _, oItem := range originalSlice {
@@ -488,7 +604,8 @@ commitLoop:
 		}
 		for _, context := range commit.Commit.Status.Contexts {
 			if strings.EqualFold(string(context.Context), "verify-conformance") {
-				currentLatestHasCurrentStatus = strings.EqualFold(string(context.State), string(githubql.StatusStateSuccess))
+				// a passing status is only kept while the result still passes, such as not after becoming a draft
+				currentLatestHasCurrentStatus = strings.EqualFold(string(context.State), string(githubql.StatusStateSuccess)) && state == "success"
 				break commitLoop
 			}
 		}
@@ -497,10 +614,12 @@ commitLoop:
 		log.Infof("PR %v has status up to date", pr.Number)
 		return nil
 	}
-	switch state {
-	case "success":
+	switch {
+	case bool(pr.IsDraft):
+		description = draftResultDescription
+	case state == "success":
 		description = "All checks are passing"
-	case "failure":
+	case state == "failure":
 		description = "Please check failing requirements and update accordingly"
 	default:
 		description = "Internal error"
@@ -532,76 +651,95 @@ commitLoop:
 // handle checks a Conformance Certification PR to determine if the contents of the PR pass sanity checks.
 // Adds a comment to indicate whether or not the version in the PR title occurs in the supplied logs.
 func handle(log *logrus.Entry, ghc githubClient, pr *suite.PullRequestQuery) error {
-	godogFeaturePaths := GetGodogPaths()
-	prSuite, err := NewPRSuiteForPR(log, ghc, pr)
+	// the data is kept for the whole check, even when the metadata is reloaded in the meantime
+	dataFS, release := common.AcquireDataFS()
+	defer release()
+	prSuite, err := NewPRSuiteForPR(log, ghc, pr, dataFS)
 	if err != nil {
 		return err
 	}
-	prSuite.MetadataFolder = path.Join(common.GetDataPath(), "conformance-testdata")
 	prSuite.SetSubmissionMetadatafromFolderStructure()
 	if !isConformancePR(pr) {
+		if pr.IsDraft {
+			log.Printf("This draft PR (%v) is not a conformance PR\n", int(pr.Number))
+			return nil
+		}
 		log.Printf("This PR (%v) is not a conformance PR\n", int(pr.Number))
-		finalComment := strings.Join(
-			[]string{
-				"This pull request appears to not be a conformance results submission, because its title doesn't include \"conformance results for\"; Checks will not run.",
-				"",
-				"If this change is intended to be verified as a conformance results submission see: " +
-					"[_content of the PR_](https://github.com/cncf/k8s-conformance/blob/master/instructions.md#contents-of-the-pr), " +
-					"and [_requirements_](https://github.com/cncf/k8s-conformance/blob/master/instructions.md#requirements)",
-			},
-			"\n")
+		finalComment, err := prSuite.RenderComment(suite.CommentTemplateNotConformance, prSuite.NewCommentData())
+		if err != nil {
+			return err
+		}
 		labels := []string{"not-conformance-product-submission", "unable-to-process"}
 		state := "pending"
 		if _, _, err := updateLabels(log, ghc, pr, prSuite, labels); err != nil {
 			return err
 		}
-		if err := updateComments(log, ghc, pr, prSuite, finalComment); err != nil {
+		report := prSuite.NewReport(finalComment, labels, state)
+		if err := updateComments(log, ghc, pr, prSuite, report); err != nil {
 			return err
 		}
-		if err := updateStatus(log, ghc, pr, prSuite, state); err != nil {
+		if err := updateResult(log, ghc, pr, prSuite, report); err != nil {
 			return err
 		}
 		return nil
 	}
 
+	if pr.IsDraft {
+		return handleDraft(log, ghc, pr, prSuite)
+	}
+
 	if err := prSuite.ItIsAValidAndSupportedRelease(); err != nil {
-		finalComment := err.Error()
-		finalComment = fmt.Sprintf("%v.", strings.ToUpper(finalComment[:1])+finalComment[1:])
+		data := prSuite.NewCommentData()
+		data.Error = err.Error()
+		finalComment, err := prSuite.RenderComment(suite.CommentTemplateUnsupportedRelease, data)
+		if err != nil {
+			return err
+		}
 		labels := []string{"conformance-product-submission", "unable-to-process"}
 		state := "pending"
 		if _, _, err := updateLabels(log, ghc, pr, prSuite, labels); err != nil {
 			return err
 		}
-		if err := updateComments(log, ghc, pr, prSuite, finalComment); err != nil {
+		report := prSuite.NewReport(finalComment, labels, state)
+		if err := updateComments(log, ghc, pr, prSuite, report); err != nil {
 			return err
 		}
-		if err := updateStatus(log, ghc, pr, prSuite, state); err != nil {
+		if err := updateResult(log, ghc, pr, prSuite, report); err != nil {
 			return err
 		}
-		return fmt.Errorf("unable to process release file as it is missing for release %v", prSuite.KubernetesReleaseVersion)
+		return fmt.Errorf("%w as it is missing for release %v", errUnableToProcess, prSuite.KubernetesReleaseVersion)
 	}
-	conformanceYAMLFilePath := path.Join(prSuite.MetadataFolder, prSuite.KubernetesReleaseVersion, "conformance.yaml")
-	if _, err := common.ReadFile(conformanceYAMLFilePath); err != nil && os.IsNotExist(err) {
-		finalComment := fmt.Sprintf("The release version %v is unable to be processed at this time; Please wait as this version may become available soon.", prSuite.KubernetesReleaseVersion)
+	if _, err := prSuite.ReadConformanceYAML(); errors.Is(err, fs.ErrNotExist) {
+		finalComment, err := prSuite.RenderComment(suite.CommentTemplateUnableToProcess, prSuite.NewCommentData())
+		if err != nil {
+			return err
+		}
 		labels := []string{"conformance-product-submission", "unable-to-process"}
 		state := "pending"
 		if _, _, err := updateLabels(log, ghc, pr, prSuite, labels); err != nil {
 			return err
 		}
-		if err := updateComments(log, ghc, pr, prSuite, finalComment); err != nil {
+		report := prSuite.NewReport(finalComment, labels, state)
+		if err := updateComments(log, ghc, pr, prSuite, report); err != nil {
 			return err
 		}
-		if err := updateStatus(log, ghc, pr, prSuite, state); err != nil {
+		if err := updateResult(log, ghc, pr, prSuite, report); err != nil {
 			return err
 		}
-		return fmt.Errorf("unable to process release file as it is missing for release %v", prSuite.KubernetesReleaseVersion)
+		return fmt.Errorf("%w as it is missing for release %v", errUnableToProcess, prSuite.KubernetesReleaseVersion)
 	}
-	prSuite.NewTestSuite(suite.PRSuiteOptions{Paths: godogFeaturePaths}).Run()
+	existingSubmissions, err := GetExistingSubmissions(ghc, string(pr.Repository.Owner.Login), string(pr.Repository.Name), string(pr.BaseRefOID), prSuite.KubernetesReleaseVersion)
+	if err != nil {
+		return fmt.Errorf("unable to find the existing submissions for PR (%v), %v", pr.Number, err)
+	}
+	prSuite.PR.ExistingSubmissions = existingSubmissions
+	prSuite.NewTestSuite(suite.PRSuiteOptions{}).Run()
 
-	finalComment, labels, state, err := prSuite.GetLabelsAndCommentsFromSuiteResultsBuffer()
+	report, err := prSuite.GetReport()
 	if err != nil {
 		return err
 	}
+	finalComment, labels := report.Comment, report.Labels
 	if finalComment == "" && len(labels) == 0 {
 		log.Printf("There is nothing new to comment on PR (%v)\n", int(prSuite.PR.Number))
 		return nil
@@ -620,19 +758,45 @@ func handle(log *logrus.Entry, ghc githubClient, pr *suite.PullRequestQuery) err
 	fmt.Println("NewLabels: ", newLabels)
 	fmt.Println("RemovedLabels: ", removedLabels)
 
-	if err := updateComments(log, ghc, pr, prSuite, finalComment); err != nil {
+	if err := updateComments(log, ghc, pr, prSuite, report); err != nil {
+		return err
+	}
+	if err := updateResult(log, ghc, pr, prSuite, report); err != nil {
+		return err
+	}
+	return nil
+}
+
+// handleDraft lets the submitter know that checks are held off until the PR is ready for review,
+// setting the result to pending so that a passing result from before it was a draft isn't shown
+func handleDraft(log *logrus.Entry, ghc githubClient, pr *suite.PullRequestQuery, prSuite *suite.PRSuite) error {
+	log.Printf("This PR (%v) is a draft\n", int(pr.Number))
+	finalComment, err := prSuite.RenderComment(suite.CommentTemplateDraft, prSuite.NewCommentData())
+	if err != nil {
+		return err
+	}
+	labels := []string{"conformance-product-submission"}
+	if _, _, err := updateLabels(log, ghc, pr, prSuite, labels); err != nil {
+		return err
+	}
+	report := prSuite.NewReport(finalComment, labels, "pending")
+	if err := updateComments(log, ghc, pr, prSuite, report); err != nil {
 		return err
 	}
-	if err := updateStatus(log, ghc, pr, prSuite, state); err != nil {
+	if err := updateResult(log, ghc, pr, prSuite, report); err != nil {
 		return err
 	}
 	return nil
 }
 
+// NewPullRequestQueryForGithubPullRequest returns a PullRequestQuery for the PR in the base repo orgName/repoName
 func NewPullRequestQueryForGithubPullRequest(orgName string, repoName string, number int, pr *github.PullRequest) *suite.PullRequestQuery {
 	return &suite.PullRequestQuery{
-		Title:  githubql.String(pr.Title),
-		Number: githubql.Int(number),
+		Title:       githubql.String(pr.Title),
+		Number:      githubql.Int(number),
+		HeadRefOID:  githubql.String(pr.Head.SHA),
+		IsDraft:     githubql.Boolean(pr.Draft),
+		BaseRefName: githubql.String(pr.Base.Ref),
 		Author: struct {
 			Login githubql.String
 		}{
@@ -648,7 +812,7 @@ func NewPullRequestQueryForGithubPullRequest(orgName string, repoName string, nu
 			Owner: struct {
 				Login githubql.String
 			}{
-				Login: githubql.String(pr.User.Login),
+				Login: githubql.String(orgName),
 			},
 		},
 	}
@@ -658,41 +822,123 @@ func NewGitHubPullRequestForPullRequestQuery(orgName string, repoName string, nu
 	return &github.PullRequest{
 		Title:  string(pr.Title),
 		Number: number,
+		Draft:  bool(pr.IsDraft),
 		User: github.User{
 			Login: string(pr.Author.Login),
 		},
+		Head: github.PullRequestBranch{
+			SHA: string(pr.HeadRefOID),
+		},
+		Base: github.PullRequestBranch{
+			Ref: string(pr.BaseRefName),
+			Repo: github.Repo{
+				Owner: github.User{Login: orgName},
+				Name:  repoName,
+			},
+		},
 	}
 }
 
+// titleChanged returns whether the title was changed in an edited pull request event
+func titleChanged(pre *github.PullRequestEvent) bool {
+	var changes struct {
+		Title *struct {
+			From string `json:"from"`
+		} `json:"title"`
+	}
+	if len(pre.Changes) == 0 {
+		return false
+	}
+	if err := json.Unmarshal(pre.Changes, &changes); err != nil {
+		return false
+	}
+	return changes.Title != nil && changes.Title.From != pre.PullRequest.Title
+}
+
 // HandlePullRequestEvent handles a GitHub pull request event
 func HandlePullRequestEvent(log *logrus.Entry, ghc githubClient, pre *github.PullRequestEvent) error {
 	log.Infof("HandlePullRequestEvent")
+	needsCheck, err := pullRequestEventNeedsCheck(ghc, pre)
+	if err != nil || !needsCheck {
+		return err
+	}
+	return checkPR(log, ghc, PRKey{Org: pre.Repo.Owner.Login, Repo: pre.Repo.Name, Number: pre.Number}, nil)
+}
+
+// pullRequestEventNeedsCheck returns whether the action of a pull request event may change the results of the checks
+func pullRequestEventNeedsCheck(ghc githubClient, pre *github.PullRequestEvent) (bool, error) {
 	switch pre.Action {
-	case github.PullRequestActionOpened, github.PullRequestActionReopened:
-	default:
-		return nil
+	case github.PullRequestActionOpened,
+		github.PullRequestActionReopened,
+		github.PullRequestActionSynchronize,
+		github.PullRequestActionReadyForReview,
+		github.PullRequestActionConvertedToDraft:
+		return true, nil
+	case github.PullRequestActionEdited:
+		return titleChanged(pre), nil
+	case github.PullRequestActionLabeled, github.PullRequestActionUnlabeled:
+		if !labelIsManagedByPlugin(pre.Label.Name) {
+			return false, nil
+		}
+		botUserChecker, err := ghc.BotUserChecker()
+		if err != nil {
+			return false, fmt.Errorf("unable to get bot name, %v", err)
+		}
+		return !botUserChecker(pre.Sender.Login), nil
 	}
+	return false, nil
+}
 
-	return handle(log, ghc, NewPullRequestQueryForGithubPullRequest(pre.Repo.Owner.Login, pre.Repo.Name, pre.Number, &pre.PullRequest))
+// checkPR runs the checks on pr, fetching the PR for key from its base repo when pr is nil
+func checkPR(log *logrus.Entry, ghc githubClient, key PRKey, pr *suite.PullRequestQuery) error {
+	if pr == nil {
+		var err error
+		if pr, err = getPullRequestQuery(context.Background(), ghc, key.Org, key.Repo, key.Number); err != nil {
+			return err
+		}
+	}
+	return handle(log, ghc, pr)
 }
 
-// HandleIssueCommentEvent handles a GitHub issue comment event and adds or removes a
-// message indicating that there are inconsitencies in the version of Kubernetes
-// referenced in the title of the PR versus the log file evidence supplied in the associated commit.
+// HandleIssueCommentEvent handles a GitHub issue comment event and runs the
+// commands for the plugin found in new comments on a PR, such as
+// "/verify-conformance recheck" to check the PR again.
+// Comments without commands and comments made by the bot are ignored.
 func HandleIssueCommentEvent(log *logrus.Entry, ghc githubClient, ice *github.IssueCommentEvent) error {
 	log.Infof("HandleIssueCommentEvent")
+	return handleIssueCommentEvent(log, ghc, ice, func(key PRKey) error {
+		return checkPR(log, ghc, key, nil)
+	})
+}
+
+// handleIssueCommentEvent runs the commands in a comment, calling recheck for the PR to be checked again
+func handleIssueCommentEvent(log *logrus.Entry, ghc githubClient, ice *github.IssueCommentEvent, recheck func(PRKey) error) error {
 	if !ice.Issue.IsPullRequest() {
 		return nil
 	}
-	pr, err := ghc.GetPullRequest(ice.Repo.Owner.Login, ice.Repo.Name, ice.Issue.Number)
+	if ice.Action != github.IssueCommentActionCreated {
+		return nil
+	}
+	cmds := parseCommands(ice.Comment.Body)
+	if len(cmds) == 0 {
+		return nil
+	}
+	botUserChecker, err := ghc.BotUserChecker()
 	if err != nil {
-		return err
+		return fmt.Errorf("unable to get bot name, %v", err)
 	}
-
-	return handle(log, ghc, NewPullRequestQueryForGithubPullRequest(ice.Repo.Owner.Login, ice.Repo.Name, ice.Issue.Number, pr))
+	if botUserChecker(ice.Comment.User.Login) {
+		return nil
+	}
+	for _, cmd := range cmds {
+		if err := handleCommand(ghc, ice, cmd, recheck); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-// HandleAll is called periodically and the period is setup in main.go
+// HandleAll is called periodically through Resync and the period is setup in main.go
 // It runs a Github Query to get all open PRs for this repo which contains k8s conformance requests
 //
 // Each PR is checked in turn, we check
@@ -704,6 +950,25 @@ func HandleIssueCommentEvent(log *logrus.Entry, ghc githubClient, ice *github.Is
 // if there is an inconsistency we add a comment that explains the problem
 // and tells the PR submitter to review the documentation
 func HandleAll(log *logrus.Entry, ghc githubClient, config *plugins.Configuration) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	q := NewQueue(log, ghc, DefaultQueueWorkers)
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		q.Run(ctx)
+	}()
+	_, err := handleAll(log, ghc, config, q)
+	cancel()
+	<-stopped
+	return err
+}
+
+// handleAll queues all open PRs in the configured repos and waits for their checks
+func handleAll(log *logrus.Entry, ghc githubClient, config *plugins.Configuration, q *Queue) (summary SweepSummary, err error) {
+	start := time.Now()
+	defer func() {
+		summary.Duration = time.Since(start)
+	}()
 	log.Infof("%v : HandleAll : Checking all PRs for handling", PluginName)
 
 	orgs, repos := config.EnabledReposForExternalPlugin(PluginName) // TODO : Overkill see below
@@ -711,7 +976,7 @@ func HandleAll(log *logrus.Entry, ghc githubClient, config *plugins.Configuratio
 
 	if len(orgs) == 0 && len(repos) == 0 {
 		log.Warnf("HandleAll : No repos have been configured for the %s plugin", PluginName)
-		return nil
+		return summary, nil
 	}
 
 	var queryOpenPRs bytes.Buffer
@@ -732,17 +997,25 @@ func HandleAll(log *logrus.Entry, ghc githubClient, config *plugins.Configuratio
 	for _, org := range orgs {
 		prSearch, err := search(context.Background(), log, ghc, queryOpenPRs.String(), org)
 		if err != nil {
-			return err
+			return summary, err
 		}
 		prs = append(prs, prSearch...)
 	}
 	log.Infof("Considering %d PRs.", len(prs))
+	summary.Considered = len(prs)
 
-	for _, pr := range prs {
-		err := handle(log, ghc, &pr)
-		if err != nil {
+	results := []<-chan error{}
+	// the PRs are checked as found by the search, rather than being fetched again
+	for i := range prs {
+		results = append(results, q.AddPR(&prs[i]))
+	}
+	for _, result := range results {
+		if err := <-result; err != nil {
 			log.Infof("error running checks on PR: %v", err)
+			summary.Failed++
+			continue
 		}
+		summary.Succeeded++
 	}
-	return nil
+	return summary, nil
 }
@@ -20,14 +20,17 @@ import (
 	"context"
 	_ "embed"
 	"fmt"
+	"html"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path"
 	"reflect"
 	"strings"
 	"testing"
 
 	"sigs.k8s.io/verify-conformance/internal/common"
+	"sigs.k8s.io/verify-conformance/internal/resolver"
 	"sigs.k8s.io/verify-conformance/internal/suite"
 
 	githubql "github.com/shurcooL/githubv4"
@@ -41,8 +44,12 @@ import (
 var (
 	log = logrus.StandardLogger().WithField("plugin", "verify-conformance")
 
-	//go:embed testdata/TestGetJunitSubmittedConformanceTests-coolkube-v1-33-junit_01.xml
-	testGetJunitSubmittedConformanceTestsCoolkubeV133Junit_01xml string
+	//go:embed testdata/TestGetJunitSubmittedConformanceTests-coolkube-v1-35-junit_01.xml
+	testGetJunitSubmittedConformanceTestsCoolkubeV135Junit_01xml string
+	//go:embed testdata/TestParseE2eLog-coolkube-v1-35-e2e.log
+	testParseE2eLogCoolkubeV135E2eLog string
+	//go:embed testdata/TestReadme-coolkube-v1-35-README.md
+	testReadmeCoolkubeV135README string
 )
 
 type prContext struct {
@@ -51,10 +58,13 @@ type prContext struct {
 	Comments         []github.IssueComment
 	HeadRefOID       string
 	Status           github.Status
+	CheckRuns        []github.CheckRun
 }
 
 type FakeGitHubClient struct {
 	PopulatedPullRequests []*prContext
+	// BaseFiles are the files on the base branch
+	BaseFiles []*suite.PullRequestFile
 }
 
 func NewFakeGitHubClient(p []*prContext) *FakeGitHubClient {
@@ -94,6 +104,53 @@ func (f *FakeGitHubClient) GetCombinedStatus(org, repo, ref string) (*github.Com
 		State: state,
 	}, nil
 }
+func (f *FakeGitHubClient) ListCheckRuns(org, repo, ref string) (*github.CheckRunList, error) {
+	list := &github.CheckRunList{}
+	for i := range f.PopulatedPullRequests {
+		if string(f.PopulatedPullRequests[i].PullRequestQuery.Repository.Owner.Login) != org ||
+			string(f.PopulatedPullRequests[i].PullRequestQuery.Repository.Name) != repo {
+			continue
+		}
+		for _, c := range f.PopulatedPullRequests[i].CheckRuns {
+			if c.HeadSHA == ref {
+				list.CheckRuns = append(list.CheckRuns, c)
+			}
+		}
+	}
+	list.Total = len(list.CheckRuns)
+	return list, nil
+}
+
+func (f *FakeGitHubClient) CreateCheckRun(org, repo string, checkRun github.CheckRun) (int64, error) {
+	var id int64
+	for i := range f.PopulatedPullRequests {
+		if string(f.PopulatedPullRequests[i].PullRequestQuery.Repository.Owner.Login) != org ||
+			string(f.PopulatedPullRequests[i].PullRequestQuery.Repository.Name) != repo {
+			continue
+		}
+		id = int64(len(f.PopulatedPullRequests[i].CheckRuns) + 1)
+		checkRun.ID = id
+		f.PopulatedPullRequests[i].CheckRuns = append(f.PopulatedPullRequests[i].CheckRuns, checkRun)
+	}
+	return id, nil
+}
+
+func (f *FakeGitHubClient) UpdateCheckRun(org, repo string, checkRunId int64, checkRun github.CheckRun) error {
+	for i := range f.PopulatedPullRequests {
+		if string(f.PopulatedPullRequests[i].PullRequestQuery.Repository.Owner.Login) != org ||
+			string(f.PopulatedPullRequests[i].PullRequestQuery.Repository.Name) != repo {
+			continue
+		}
+		for j := range f.PopulatedPullRequests[i].CheckRuns {
+			if f.PopulatedPullRequests[i].CheckRuns[j].ID == checkRunId {
+				checkRun.ID = checkRunId
+				f.PopulatedPullRequests[i].CheckRuns[j] = checkRun
+			}
+		}
+	}
+	return nil
+}
+
 func (f *FakeGitHubClient) GetIssueLabels(org, repo string, number int) ([]github.Label, error) {
 	labels := []github.Label{}
 	var prIndex *int
@@ -121,6 +178,7 @@ func (f *FakeGitHubClient) CreateComment(org, repo string, number int, comment s
 		return fmt.Errorf("unable make comment '%v'", number)
 	}
 	f.PopulatedPullRequests[*prIndex].Comments = append(f.PopulatedPullRequests[*prIndex].Comments, github.IssueComment{
+		ID:   len(f.PopulatedPullRequests[*prIndex].Comments) + 1,
 		Body: comment,
 		User: github.User{
 			Login: "cncfci(bot)",
@@ -128,6 +186,17 @@ func (f *FakeGitHubClient) CreateComment(org, repo string, number int, comment s
 	})
 	return nil
 }
+func (f *FakeGitHubClient) EditComment(org, repo string, id int, comment string) error {
+	for i := range f.PopulatedPullRequests {
+		for j := range f.PopulatedPullRequests[i].Comments {
+			if f.PopulatedPullRequests[i].Comments[j].ID == id {
+				f.PopulatedPullRequests[i].Comments[j].Body = comment
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("unable to find comment '%v'", id)
+}
 func (f *FakeGitHubClient) ListIssueCommentsWithContext(ctx context.Context, org, repo string, number int) ([]github.IssueComment, error) {
 	var prIndex *int
 	for i := range f.PopulatedPullRequests {
@@ -141,7 +210,7 @@ func (f *FakeGitHubClient) ListIssueCommentsWithContext(ctx context.Context, org
 	return f.PopulatedPullRequests[*prIndex].Comments, nil
 }
 func (f *FakeGitHubClient) BotUserChecker() (func(candidate string) bool, error) {
-	return func(string) bool { return false }, nil
+	return func(candidate string) bool { return candidate == "cncfci(bot)" }, nil
 }
 func (f *FakeGitHubClient) AddLabel(org, repo string, number int, label string) error {
 	var prIndex *int
@@ -179,6 +248,24 @@ func (f *FakeGitHubClient) RemoveLabel(org, repo string, number int, label strin
 	return nil
 }
 func (f *FakeGitHubClient) DeleteStaleComments(org, repo string, number int, comments []github.IssueComment, isStale func(github.IssueComment) bool) error {
+	stale := map[int]bool{}
+	for _, c := range comments {
+		if isStale(c) {
+			stale[c.ID] = true
+		}
+	}
+	for i := range f.PopulatedPullRequests {
+		if f.PopulatedPullRequests[i].PullRequestQuery.Number != githubql.Int(number) {
+			continue
+		}
+		kept := []github.IssueComment{}
+		for _, c := range f.PopulatedPullRequests[i].Comments {
+			if !stale[c.ID] {
+				kept = append(kept, c)
+			}
+		}
+		f.PopulatedPullRequests[i].Comments = kept
+	}
 	return nil
 }
 func (f *FakeGitHubClient) QueryWithGitHubAppsSupport(ctx context.Context, sq interface{}, vars map[string]interface{}, org string) error {
@@ -189,6 +276,15 @@ func (f *FakeGitHubClient) QueryWithGitHubAppsSupport(ctx context.Context, sq in
 	if len(f.PopulatedPullRequests) > 0 && f.PopulatedPullRequests[0] == nil {
 		return fmt.Errorf("empty pr")
 	}
+	if query, ok := sq.(*PullRequestByNumberQuery); ok {
+		for _, pr := range f.PopulatedPullRequests {
+			if pr.PullRequestQuery != nil && pr.PullRequestQuery.Number == vars["number"].(githubql.Int) {
+				query.Repository.PullRequest = *pr.PullRequestQuery
+				return nil
+			}
+		}
+		return fmt.Errorf("unable to find pr '%v'", vars["number"])
+	}
 	nodes := func() []struct {
 		PullRequest suite.PullRequestQuery "graphql:\"... on PullRequest\""
 	} {
@@ -271,6 +367,30 @@ func (f *FakeGitHubClient) GetPullRequest(org, repo string, number int) (*github
 	}
 	return NewGitHubPullRequestForPullRequestQuery(org, repo, number, f.PopulatedPullRequests[*prIndex].PullRequestQuery), nil
 }
+func (f *FakeGitHubClient) GetDirectory(org, repo, dirpath, commit string) ([]github.DirectoryContent, error) {
+	contents := []github.DirectoryContent{}
+	seen := map[string]bool{}
+	for _, file := range f.BaseFiles {
+		folder := path.Dir(file.Name)
+		if path.Dir(folder) != dirpath || seen[folder] {
+			continue
+		}
+		seen[folder] = true
+		contents = append(contents, github.DirectoryContent{Type: "dir", Name: path.Base(folder), Path: folder})
+	}
+	if len(contents) == 0 {
+		return nil, &github.FileNotFound{}
+	}
+	return contents, nil
+}
+func (f *FakeGitHubClient) GetFile(org, repo, filepath, commit string) ([]byte, error) {
+	for _, file := range f.BaseFiles {
+		if file.Name == filepath {
+			return []byte(file.Contents), nil
+		}
+	}
+	return nil, &github.FileNotFound{}
+}
 func (f *FakeGitHubClient) GetPullRequestChanges(org, repo string, number int) ([]github.PullRequestChange, error) {
 	pr := &prContext{}
 	prChanges := []github.PullRequestChange{}
@@ -289,6 +409,12 @@ func (f *FakeGitHubClient) GetPullRequestChanges(org, repo string, number int) (
 	return prChanges, nil
 }
 
+func TestMain(m *testing.M) {
+	// the PRODUCT.yaml URLs in the tests are served by httptest on loopback addresses
+	productYAMLURLResolver = resolver.New(resolver.Options{AllowPrivateAddresses: true})
+	os.Exit(m.Run())
+}
+
 func TestHelpProvider(t *testing.T) {
 	hp, err := HelpProvider([]config.OrgRepo{})
 	if err != nil {
@@ -321,6 +447,219 @@ func Test_fetchFileFromURI(t *testing.T) {
 
 }
 
+func TestFetchProductLogo(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/logo.svg":
+			w.Header().Set("Content-Type", "image/svg+xml")
+			_, _ = w.Write([]byte(`<svg xmlns="http://www.w3.org/2000/svg"/>`))
+		case "/large.svg":
+			w.Header().Set("Content-Type", "image/svg+xml")
+			_, _ = w.Write([]byte(strings.Repeat(" ", suite.MaxProductLogoSize+1)))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer svr.Close()
+
+	type testCase struct {
+		Name                string
+		URL                 string
+		ExpectedContentType string
+		ExpectedContents    string
+		ExpectedError       string
+	}
+
+	for _, tc := range []testCase{
+		{
+			Name:                "svg",
+			URL:                 svr.URL + "/logo.svg",
+			ExpectedContentType: "image/svg+xml",
+			ExpectedContents:    `<svg xmlns="http://www.w3.org/2000/svg"/>`,
+		},
+		{
+			Name:          "too large",
+			URL:           svr.URL + "/large.svg",
+			ExpectedError: "it is larger than the limit of 1048576 bytes",
+		},
+		{
+			Name:          "not found",
+			URL:           svr.URL + "/missing.svg",
+			ExpectedError: "the server responded with status 404 Not Found",
+		},
+		{
+			Name:          "invalid url",
+			URL:           "logo.svg",
+			ExpectedError: "it is not a valid URL",
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			logo := FetchProductLogo(tc.URL)
+			if logo.URL != tc.URL {
+				t.Fatalf("error: unexpected url; want = %v; got = %v", tc.URL, logo.URL)
+			}
+			if !strings.Contains(logo.Error, tc.ExpectedError) || (tc.ExpectedError == "" && logo.Error != "") {
+				t.Fatalf("error: expected error containing '%v'; got = %v", tc.ExpectedError, logo.Error)
+			}
+			if tc.ExpectedError != "" {
+				return
+			}
+			if logo.ContentType != tc.ExpectedContentType {
+				t.Fatalf("error: unexpected content type; want = %v; got = %v", tc.ExpectedContentType, logo.ContentType)
+			}
+			if string(logo.Contents) != tc.ExpectedContents {
+				t.Fatalf("error: unexpected contents; want = %v; got = %v", tc.ExpectedContents, string(logo.Contents))
+			}
+		})
+	}
+}
+
+func TestResolveProductYAMLURLDataTypes(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/":
+			w.Header().Set("Content-Type", "text/html")
+		case "/docs":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		case "/repo":
+			http.Redirect(w, r, "/repo.tar.gz", http.StatusFound)
+		case "/repo.tar.gz":
+			w.Header().Set("Content-Type", "application/gzip")
+		}
+	}))
+	defer svr.Close()
+
+	prSuite := suite.NewPRSuite(&suite.PullRequest{})
+	ResolveProductYAMLURLDataTypes(logrus.WithField("plugin", PluginName), prSuite, fmt.Sprintf(`website_url: %[1]v/
+repo_url: %[1]v/repo
+documentation_url: %[1]v/docs`, svr.URL))
+
+	expected := map[string]string{
+		"website_url":       "text/html",
+		"repo_url":          "application/gzip",
+		"documentation_url": "text/html; charset=utf-8",
+	}
+	if !reflect.DeepEqual(prSuite.PR.ProductYAMLURLDataTypes, expected) {
+		t.Fatalf("error: unexpected data types; want = %v; got = %v", expected, prSuite.PR.ProductYAMLURLDataTypes)
+	}
+	if r := prSuite.PR.ProductYAMLURLResults["documentation_url"]; r.Method != http.MethodGet {
+		t.Fatalf("error: expected documentation_url to be resolved with GET after HEAD was rejected; got = %v", r.Method)
+	}
+	if r := prSuite.PR.ProductYAMLURLResults["repo_url"]; !reflect.DeepEqual(r.Redirects, []string{svr.URL + "/repo.tar.gz"}) {
+		t.Fatalf("error: expected the redirect of repo_url to be reported; got = %v", r.Redirects)
+	}
+}
+
+// countingGitHubClient counts the reads of the base branch
+type countingGitHubClient struct {
+	*FakeGitHubClient
+	directoryReads int
+	fileReads      int
+	err            error
+}
+
+func (c *countingGitHubClient) GetDirectory(org, repo, dirpath, commit string) ([]github.DirectoryContent, error) {
+	c.directoryReads++
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.FakeGitHubClient.GetDirectory(org, repo, dirpath, commit)
+}
+
+func (c *countingGitHubClient) GetFile(org, repo, filepath, commit string) ([]byte, error) {
+	c.fileReads++
+	return c.FakeGitHubClient.GetFile(org, repo, filepath, commit)
+}
+
+func TestGetExistingSubmissions(t *testing.T) {
+	fake := &FakeGitHubClient{
+		BaseFiles: []*suite.PullRequestFile{
+			{Name: "v1.35/coolkube/PRODUCT.yaml", Contents: "vendor: Cool\nname: coolkube"},
+			{Name: "v1.35/coolkube/README.md", Contents: "# coolkube"},
+			{Name: "v1.35/warmkube/PRODUCT.yaml", Contents: "vendor: Warm\nname: warmkube"},
+			{Name: "v1.35/coolkube-enterprise/README.md", Contents: "# coolkube-enterprise"},
+			{Name: "v1.34/coolkube/PRODUCT.yaml", Contents: "vendor: Cool\nname: coolkube"},
+		},
+	}
+
+	type testCase struct {
+		Name                   string
+		Ref                    string
+		Release                string
+		Err                    error
+		Expected               []*suite.ExistingSubmission
+		ExpectedDirectoryReads int
+		ExpectedFileReads      int
+		ExpectedErrorString    string
+	}
+
+	for _, tc := range []testCase{
+		{
+			Name:    "release with submissions",
+			Ref:     "1111111",
+			Release: "v1.35",
+			Expected: []*suite.ExistingSubmission{
+				{Folder: "v1.35/coolkube", ProductYAML: &suite.ProductYAML{Vendor: "Cool", Name: "coolkube"}},
+				{Folder: "v1.35/warmkube", ProductYAML: &suite.ProductYAML{Vendor: "Warm", Name: "warmkube"}},
+				{Folder: "v1.35/coolkube-enterprise"},
+			},
+			ExpectedDirectoryReads: 1,
+			ExpectedFileReads:      3,
+		},
+		{
+			Name:    "cached for the same base commit",
+			Ref:     "1111111",
+			Release: "v1.35",
+			Expected: []*suite.ExistingSubmission{
+				{Folder: "v1.35/coolkube", ProductYAML: &suite.ProductYAML{Vendor: "Cool", Name: "coolkube"}},
+				{Folder: "v1.35/warmkube", ProductYAML: &suite.ProductYAML{Vendor: "Warm", Name: "warmkube"}},
+				{Folder: "v1.35/coolkube-enterprise"},
+			},
+		},
+		{
+			Name:                   "release without submissions",
+			Ref:                    "1111111",
+			Release:                "v1.36",
+			Expected:               []*suite.ExistingSubmission{},
+			ExpectedDirectoryReads: 1,
+		},
+		{
+			Name:     "no release",
+			Ref:      "1111111",
+			Expected: []*suite.ExistingSubmission{},
+		},
+		{
+			Name:                   "unable to list the release",
+			Ref:                    "2222222",
+			Release:                "v1.35",
+			Err:                    fmt.Errorf("rate limited"),
+			ExpectedDirectoryReads: 1,
+			ExpectedErrorString:    "unable to list 'v1.35' on '2222222', rate limited",
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			ghc := &countingGitHubClient{FakeGitHubClient: fake, err: tc.Err}
+			existing, err := GetExistingSubmissions(ghc, "cncf", "k8s-conformance", tc.Ref, tc.Release)
+			if tc.ExpectedErrorString == "" && err != nil {
+				t.Fatalf("error: unexpected error: %v", err)
+			}
+			if tc.ExpectedErrorString != "" && (err == nil || err.Error() != tc.ExpectedErrorString) {
+				t.Fatalf("error: expected error '%v'; got = %v", tc.ExpectedErrorString, err)
+			}
+			if !reflect.DeepEqual(existing, tc.Expected) {
+				t.Fatalf("error: unexpected existing submissions; want = %+v; got = %+v", tc.Expected, existing)
+			}
+			if ghc.directoryReads != tc.ExpectedDirectoryReads || ghc.fileReads != tc.ExpectedFileReads {
+				t.Fatalf("error: expected %v directory and %v file reads; got = %v and %v", tc.ExpectedDirectoryReads, tc.ExpectedFileReads, ghc.directoryReads, ghc.fileReads)
+			}
+		})
+	}
+}
+
 func Test_rawURLForBlobURL(t *testing.T) {
 	type testCase struct {
 		BlobURL           string
@@ -424,8 +763,6 @@ func TestNewPRSuiteForPR(t *testing.T) {
 		ExpectedErrorString string
 	}
 
-	common.DataPathPrefix = "../../"
-
 	for _, tc := range []testCase{
 		{
 			Name: "valid pull request entry",
@@ -448,18 +785,18 @@ func TestNewPRSuiteForPR(t *testing.T) {
 			},
 			SupportingFiles: []*suite.PullRequestFile{
 				{
-					Name:     "v1.33/coolkube/README.md",
+					Name:     "v1.35/coolkube/README.md",
 					BaseName: "README.md",
 					BlobURL:  "README.md",
 					Contents: `# CoolKube`,
 				},
 				{
-					Name:     "v1.33/coolkube/PRODUCT.yaml",
+					Name:     "v1.35/coolkube/PRODUCT.yaml",
 					BaseName: "PRODUCT.yaml",
 					BlobURL:  "PRODUCT.yaml",
 					Contents: `vendor: "cool"
 name: "coolkube"
-version: "v1.33"
+version: "v1.35"
 type: "distribution"
 description: "it's just all-round cool and probably the best k8s, idk"
 website_url: "website_url"
@@ -467,13 +804,13 @@ documentation_url: "docs"
 contact_email_address: "sales@coolkubernetes.com"`,
 				},
 				{
-					Name:     "v1.33/coolkube/junit_01.xml",
+					Name:     "v1.35/coolkube/junit_01.xml",
 					BaseName: "junit_01.xml",
 					BlobURL:  "junit_01.xml",
-					Contents: testGetJunitSubmittedConformanceTestsCoolkubeV133Junit_01xml,
+					Contents: testGetJunitSubmittedConformanceTestsCoolkubeV135Junit_01xml,
 				},
 				{
-					Name:     "v1.33/coolkube/e2e.log",
+					Name:     "v1.35/coolkube/e2e.log",
 					BaseName: "e2e.log",
 					BlobURL:  "e2e.log",
 					Contents: `cool!`,
@@ -502,6 +839,7 @@ contact_email_address: "sales@coolkubernetes.com"`,
 					supportingFile = file
 				}
 			}
+			w.Header().Set("Content-Type", "text/html")
 			w.WriteHeader(http.StatusOK)
 			_, err := w.Write([]byte(supportingFile.Contents))
 			if err != nil {
@@ -530,7 +868,7 @@ contact_email_address: "sales@coolkubernetes.com"`,
 				SupportingFiles:  tc.SupportingFiles,
 			},
 		})
-		prSuite, err := NewPRSuiteForPR(log, ghc, tc.PullRequestQuery)
+		prSuite, err := NewPRSuiteForPR(log, ghc, tc.PullRequestQuery, common.GetDataFS())
 		if err != nil && strings.Contains(err.Error(), tc.ExpectedErrorString) {
 			t.Fatalf("unexpected error in testcase '%v': %v", tc.Name, err)
 		}
@@ -538,20 +876,6 @@ contact_email_address: "sales@coolkubernetes.com"`,
 	}
 }
 
-func TestGetGodogPaths(t *testing.T) {
-	paths := GetGodogPaths()
-	found := false
-	for _, p := range paths {
-		if p == "../../kodata/features/" || p == "./kodata/features/" {
-			found = true
-			break
-		}
-	}
-	if !found {
-		t.Fatalf("error: unable to find features folder for godog paths")
-	}
-}
-
 func Test_labelIsManaged(t *testing.T) {
 	type testCase struct {
 		Label          string
@@ -607,8 +931,8 @@ func Test_labelIsVersionLabel(t *testing.T) {
 
 	for _, tc := range []testCase{
 		{
-			Label:          "release-v1.33",
-			Version:        "v1.33",
+			Label:          "release-v1.35",
+			Version:        "v1.35",
 			ExpectedResult: true,
 		},
 		{
@@ -617,8 +941,8 @@ func Test_labelIsVersionLabel(t *testing.T) {
 			ExpectedResult: true,
 		},
 		{
-			Label:          "no-failed-tests-v1.33",
-			Version:        "v1.33",
+			Label:          "no-failed-tests-v1.35",
+			Version:        "v1.35",
 			ExpectedResult: true,
 		},
 		{
@@ -627,18 +951,18 @@ func Test_labelIsVersionLabel(t *testing.T) {
 			ExpectedResult: true,
 		},
 		{
-			Label:          "tests-verified-v1.33",
-			Version:        "v1.33",
+			Label:          "tests-verified-v1.35",
+			Version:        "v1.35",
 			ExpectedResult: true,
 		},
 		{
-			Label:          "am-i-a-label-v1.33",
-			Version:        "v1.33",
+			Label:          "am-i-a-label-v1.35",
+			Version:        "v1.35",
 			ExpectedResult: false,
 		},
 		{
 			Label:          "thing",
-			Version:        "v1.33",
+			Version:        "v1.35",
 			ExpectedResult: false,
 		},
 	} {
@@ -741,15 +1065,13 @@ func Test_removeSliceOfStringsFromStringSlice(t *testing.T) {
 }
 
 func Test_handle(t *testing.T) {
-	if err := os.Setenv("KO_DATA_PATH", "./../../kodata"); err != nil {
-		log.Fatalf("failed to set env: %v", err)
-	}
 	type testCase struct {
 		Name                    string
 		KubernetesVersion       *string
 		KubernetesVersionLatest *string
 		PullRequestQuery        *suite.PullRequestQuery
 		SupportingFiles         []*suite.PullRequestFile
+		BaseFiles               []*suite.PullRequestFile
 		Labels                  []string
 		ExpectedLabels          []string
 		ExpectedComment         string
@@ -761,30 +1083,24 @@ func Test_handle(t *testing.T) {
 		{
 			Name:                    "valid submission",
 			Labels:                  []string{"conformance-product-submission"},
-			KubernetesVersion:       common.Pointer("v1.33"),
-			KubernetesVersionLatest: common.Pointer("v1.33"),
+			KubernetesVersion:       common.Pointer("v1.35"),
+			KubernetesVersionLatest: common.Pointer("v1.35"),
 			ExpectedComment:         "have passed for the submission",
 			ExpectedStatus:          "success",
-			ExpectedLabels:          []string{"conformance-product-submission", "tests-verified-v1.33", "no-failed-tests-v1.33", "release-v1.33", "release-documents-checked"},
+			ExpectedLabels:          []string{"conformance-product-submission", "tests-verified-v1.35", "no-failed-tests-v1.35", "release-v1.35", "release-documents-checked"},
 			SupportingFiles: []*suite.PullRequestFile{
 				{
-					Name:     "v1.33/coolkube/README.md",
+					Name:     "v1.35/coolkube/README.md",
 					BaseName: "README.md",
-					Contents: `# coolkube
-> the coolest Kubernetes distribution
-
-## Generating conformance results
-
-1. create a coolkube cluster
-2. sonobuoy run --wait && sonobuoy results "$(sonobuoy retrieve)" && sonobuoy delete --wait`,
-					BlobURL: "README.md",
+					Contents: testReadmeCoolkubeV135README,
+					BlobURL:  "README.md",
 				},
 				{
-					Name:     "v1.33/coolkube/PRODUCT.yaml",
+					Name:     "v1.35/coolkube/PRODUCT.yaml",
 					BaseName: "PRODUCT.yaml",
 					Contents: `vendor: "cool"
 name: "coolkube"
-version: "v1.33"
+version: "v1.35"
 type: "distribution"
 description: "it's just all-round cool and probably the best k8s, idk"
 website_url: "website_url"
@@ -793,20 +1109,20 @@ contact_email_address: "sales@coolkubernetes.com"`,
 					BlobURL: "PRODUCT.yaml",
 				},
 				{
-					Name:     "v1.33/coolkube/e2e.log",
+					Name:     "v1.35/coolkube/e2e.log",
 					BaseName: "e2e.log",
-					Contents: "12345",
+					Contents: testParseE2eLogCoolkubeV135E2eLog,
 					BlobURL:  "e2e.log",
 				},
 				{
-					Name:     "v1.33/coolkube/junit_01.xml",
+					Name:     "v1.35/coolkube/junit_01.xml",
 					BaseName: "junit_01.xml",
-					Contents: testGetJunitSubmittedConformanceTestsCoolkubeV133Junit_01xml,
+					Contents: testGetJunitSubmittedConformanceTestsCoolkubeV135Junit_01xml,
 					BlobURL:  "junit_01.xml",
 				},
 			},
 			PullRequestQuery: &suite.PullRequestQuery{
-				Title:  githubql.String("Conformance results for v1.33/coolkube"),
+				Title:  githubql.String("Conformance results for v1.35/coolkube"),
 				Number: githubql.Int(0),
 				Commits: struct {
 					Nodes []struct {
@@ -874,14 +1190,8 @@ contact_email_address: "sales@coolkubernetes.com"`,
 				{
 					Name:     "v1.57/coolkube/README.md",
 					BaseName: "README.md",
-					Contents: `# coolkube
-> the coolest Kubernetes distribution
-
-## Generating conformance results
-
-1. create a coolkube cluster
-2. sonobuoy run --wait && sonobuoy results "$(sonobuoy retrieve)" && sonobuoy delete --wait`,
-					BlobURL: "README.md",
+					Contents: testReadmeCoolkubeV135README,
+					BlobURL:  "README.md",
 				},
 				{
 					Name:     "v1.57/coolkube/PRODUCT.yaml",
@@ -905,7 +1215,7 @@ contact_email_address: "sales@coolkubernetes.com"`,
 				{
 					Name:     "v1.57/coolkube/junit_01.xml",
 					BaseName: "junit_01.xml",
-					Contents: testGetJunitSubmittedConformanceTestsCoolkubeV133Junit_01xml,
+					Contents: testGetJunitSubmittedConformanceTestsCoolkubeV135Junit_01xml,
 					BlobURL:  "junit_01.xml",
 				},
 			},
@@ -972,6 +1282,55 @@ contact_email_address: "sales@coolkubernetes.com"`,
 				},
 			},
 		},
+		{
+			Name:                    "duplicate under a different folder name",
+			Labels:                  []string{"conformance-product-submission"},
+			KubernetesVersion:       common.Pointer("v1.35"),
+			KubernetesVersionLatest: common.Pointer("v1.35"),
+			ExpectedComment:         "the folder 'v1.35/acme-k8s' is the product 'coolkube' by 'cool', which is close to 'coolkube' by 'cool'",
+			ExpectedLabels:          []string{"conformance-product-submission", "tests-verified-v1.35", "no-failed-tests-v1.35", "release-v1.35", "not-verifiable"},
+			SupportingFiles: []*suite.PullRequestFile{
+				{
+					Name:     "v1.35/coolkube/README.md",
+					BaseName: "README.md",
+					Contents: testReadmeCoolkubeV135README,
+					BlobURL:  "README.md",
+				},
+				{
+					Name:     "v1.35/coolkube/PRODUCT.yaml",
+					BaseName: "PRODUCT.yaml",
+					Contents: `vendor: "cool"
+name: "coolkube"
+version: "v1.35"
+type: "distribution"
+description: "it's just all-round cool and probably the best k8s, idk"
+website_url: "website_url"
+documentation_url: "docs"
+contact_email_address: "sales@coolkubernetes.com"`,
+					BlobURL: "PRODUCT.yaml",
+				},
+				{
+					Name:     "v1.35/coolkube/e2e.log",
+					BaseName: "e2e.log",
+					Contents: testParseE2eLogCoolkubeV135E2eLog,
+					BlobURL:  "e2e.log",
+				},
+				{
+					Name:     "v1.35/coolkube/junit_01.xml",
+					BaseName: "junit_01.xml",
+					Contents: testGetJunitSubmittedConformanceTestsCoolkubeV135Junit_01xml,
+					BlobURL:  "junit_01.xml",
+				},
+			},
+			BaseFiles: []*suite.PullRequestFile{
+				{Name: "v1.35/acme-k8s/PRODUCT.yaml", Contents: "vendor: \"cool\"\nname: \"coolkube\""},
+			},
+			PullRequestQuery: &suite.PullRequestQuery{
+				Title:      githubql.String("Conformance results for v1.35/coolkube"),
+				Number:     githubql.Int(0),
+				BaseRefOID: githubql.String("3333333"),
+			},
+		},
 		{
 			Name: "not a conformance pr",
 			PullRequestQuery: &suite.PullRequestQuery{
@@ -1003,6 +1362,7 @@ contact_email_address: "sales@coolkubernetes.com"`,
 						supportingFile = file
 					}
 				}
+				w.Header().Set("Content-Type", "text/html")
 				w.WriteHeader(http.StatusOK)
 				_, err := w.Write([]byte(supportingFile.Contents))
 				if err != nil {
@@ -1031,6 +1391,7 @@ contact_email_address: "sales@coolkubernetes.com"`,
 					SupportingFiles:  tc.SupportingFiles,
 				},
 			})
+			ghc.BaseFiles = tc.BaseFiles
 			if err := handle(log, ghc, tc.PullRequestQuery); err != nil && !strings.Contains(err.Error(), tc.ExpectedError) {
 				t.Fatalf("unexpected error: %v", err)
 			}
@@ -1038,7 +1399,7 @@ contact_email_address: "sales@coolkubernetes.com"`,
 				found := false
 				got := ""
 				for _, comment := range ghc.PopulatedPullRequests[tc.PullRequestQuery.Number].Comments {
-					if strings.Contains(comment.Body, tc.ExpectedComment) {
+					if strings.Contains(html.UnescapeString(comment.Body), tc.ExpectedComment) {
 						got = comment.Body
 						found = true
 					}
@@ -1062,7 +1423,7 @@ contact_email_address: "sales@coolkubernetes.com"`,
 }
 
 func TestNewPullRequestQueryForGithubPullRequest(t *testing.T) {
-	if prq := NewPullRequestQueryForGithubPullRequest(
+	prq := NewPullRequestQueryForGithubPullRequest(
 		"cncf",
 		"k8s-conformance",
 		0,
@@ -1070,10 +1431,65 @@ func TestNewPullRequestQueryForGithubPullRequest(t *testing.T) {
 			User: github.User{
 				Login: "cncf-ci",
 			},
+			Head: github.PullRequestBranch{
+				SHA: "abc123",
+				Repo: github.Repo{
+					Owner: github.User{
+						Login: "cncf-ci",
+					},
+					Name: "k8s-conformance",
+				},
+			},
 		},
-	); prq == nil {
+	)
+	if prq == nil {
 		t.Fatalf("PullRequestQuery must never be empty")
 	}
+	if want, got := "cncf", string(prq.Repository.Owner.Login); want != got {
+		t.Fatalf("unexpected repository owner: want = %v; got = %v", want, got)
+	}
+	if want, got := "cncf-ci", string(prq.Author.Login); want != got {
+		t.Fatalf("unexpected author: want = %v; got = %v", want, got)
+	}
+	if want, got := "abc123", string(prq.HeadRefOID); want != got {
+		t.Fatalf("unexpected head ref oid: want = %v; got = %v", want, got)
+	}
+}
+
+func Test_getPullRequestQuery(t *testing.T) {
+	pr := &suite.PullRequestQuery{
+		Number:     githubql.Int(12345),
+		HeadRefOID: githubql.String("abc123"),
+	}
+	pr.Repository.Owner.Login = "cncf"
+	pr.Repository.Name = "k8s-conformance"
+	pr.Commits.Nodes = append(pr.Commits.Nodes, struct {
+		Commit struct {
+			Oid    githubql.String
+			Status struct {
+				Contexts []struct {
+					Context githubql.String
+					State   githubql.String
+				}
+			}
+		}
+	}{})
+	pr.Commits.Nodes[0].Commit.Oid = "abc123"
+	ghc := NewFakeGitHubClient([]*prContext{{PullRequestQuery: pr}})
+
+	got, err := getPullRequestQuery(context.TODO(), ghc, "cncf", "k8s-conformance", 12345)
+	if err != nil {
+		t.Fatalf("error: unexpected error fetching pr: %v", err)
+	}
+	if !reflect.DeepEqual(got, pr) {
+		t.Fatalf("unexpected pr: want = %v; got = %v", pr, got)
+	}
+	if _, err := getPullRequestQuery(context.TODO(), ghc, "cncf", "k8s-conformance", 1); err == nil {
+		t.Fatalf("error: expected error fetching missing pr")
+	}
+	if _, err := getPullRequestQuery(context.TODO(), ghc, "nil", "k8s-conformance", 12345); err == nil {
+		t.Fatalf("error: expected error fetching pr from missing org")
+	}
 }
 
 func TestHandlePullRequestEvent(t *testing.T) {
@@ -1086,28 +1502,25 @@ func TestHandlePullRequestEvent(t *testing.T) {
 		supportingFiles []*suite.PullRequestFile
 		args            args
 		wantErr         bool
+		wantComment     string
+		wantNoComments  bool
+		wantStatusSHA   string
 	}{
 		{
 			name: "basic",
 			supportingFiles: []*suite.PullRequestFile{
 				{
-					Name:     "v1.33/coolkube/README.md",
+					Name:     "v1.35/coolkube/README.md",
 					BaseName: "README.md",
-					Contents: `# coolkube
-> the coolest Kubernetes distribution
-
-## Generating conformance results
-
-1. create a coolkube cluster
-2. sonobuoy run --wait && sonobuoy results "$(sonobuoy retrieve)" && sonobuoy delete --wait`,
-					BlobURL: "README.md",
+					Contents: testReadmeCoolkubeV135README,
+					BlobURL:  "README.md",
 				},
 				{
-					Name:     "v1.33/coolkube/PRODUCT.yaml",
+					Name:     "v1.35/coolkube/PRODUCT.yaml",
 					BaseName: "PRODUCT.yaml",
 					Contents: `vendor: "cool"
 name: "coolkube"
-version: "v1.33"
+version: "v1.35"
 type: "distribution"
 description: "it's just all-round cool and probably the best k8s, idk"
 website_url: "website_url"
@@ -1116,15 +1529,15 @@ contact_email_address: "sales@coolkubernetes.com"`,
 					BlobURL: "PRODUCT.yaml",
 				},
 				{
-					Name:     "v1.33/coolkube/e2e.log",
+					Name:     "v1.35/coolkube/e2e.log",
 					BaseName: "e2e.log",
 					Contents: "",
 					BlobURL:  "e2e.log",
 				},
 				{
-					Name:     "v1.33/coolkube/junit_01.xml",
+					Name:     "v1.35/coolkube/junit_01.xml",
 					BaseName: "junit_01.xml",
-					Contents: testGetJunitSubmittedConformanceTestsCoolkubeV133Junit_01xml,
+					Contents: testGetJunitSubmittedConformanceTestsCoolkubeV135Junit_01xml,
 					BlobURL:  "junit_01.xml",
 				},
 			},
@@ -1140,13 +1553,46 @@ contact_email_address: "sales@coolkubernetes.com"`,
 					},
 					Number: 12345,
 					PullRequest: github.PullRequest{
-						Title: "Conformance results for v1.33/coolkube",
+						Title: "Conformance results for v1.35/coolkube",
+						User: github.User{
+							Login: "example",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "pr from a fork",
+			args: args{
+				log: log,
+				pre: &github.PullRequestEvent{
+					Action: github.PullRequestActionSynchronize,
+					Repo: github.Repo{
+						Owner: github.User{
+							Login: "cncf",
+						},
+						Name: "k8s-conformance",
+					},
+					Number: 12345,
+					PullRequest: github.PullRequest{
+						Title: "soup recipes for winter",
 						User: github.User{
 							Login: "example",
 						},
+						Head: github.PullRequestBranch{
+							SHA: "abc123",
+							Repo: github.Repo{
+								Owner: github.User{
+									Login: "example",
+								},
+								Name: "k8s-conformance",
+							},
+						},
 					},
 				},
 			},
+			wantComment:   "appears to not be a conformance results submission",
+			wantStatusSHA: "abc123",
 		},
 		{
 			name: "request not open or reopen",
@@ -1156,6 +1602,105 @@ contact_email_address: "sales@coolkubernetes.com"`,
 					Action: github.PullRequestActionClosed,
 				},
 			},
+			wantNoComments: true,
+		},
+		{
+			name: "synchronize",
+			args: args{
+				log: log,
+				pre: &github.PullRequestEvent{
+					Action: github.PullRequestActionSynchronize,
+					PullRequest: github.PullRequest{
+						Title: "soup recipes for winter",
+					},
+				},
+			},
+			wantComment: "appears to not be a conformance results submission",
+		},
+		{
+			name: "edited without a title change",
+			args: args{
+				log: log,
+				pre: &github.PullRequestEvent{
+					Action:  github.PullRequestActionEdited,
+					Changes: []byte(`{"body":{"from":"hello"}}`),
+					PullRequest: github.PullRequest{
+						Title: "soup recipes for winter",
+					},
+				},
+			},
+			wantNoComments: true,
+		},
+		{
+			name: "edited with a title change",
+			args: args{
+				log: log,
+				pre: &github.PullRequestEvent{
+					Action:  github.PullRequestActionEdited,
+					Changes: []byte(`{"title":{"from":"soup recipes"}}`),
+					PullRequest: github.PullRequest{
+						Title: "soup recipes for winter",
+					},
+				},
+			},
+			wantComment: "appears to not be a conformance results submission",
+		},
+		{
+			name: "labeled with an unmanaged label",
+			args: args{
+				log: log,
+				pre: &github.PullRequestEvent{
+					Action: github.PullRequestActionLabeled,
+					Label:  github.Label{Name: "lgtm"},
+					PullRequest: github.PullRequest{
+						Title: "soup recipes for winter",
+					},
+				},
+			},
+			wantNoComments: true,
+		},
+		{
+			name: "unlabeled a managed label",
+			args: args{
+				log: log,
+				pre: &github.PullRequestEvent{
+					Action: github.PullRequestActionUnlabeled,
+					Label:  github.Label{Name: "release-v1.35"},
+					Sender: github.User{Login: "someone"},
+					PullRequest: github.PullRequest{
+						Title: "soup recipes for winter",
+					},
+				},
+			},
+			wantComment: "appears to not be a conformance results submission",
+		},
+		{
+			name: "draft",
+			args: args{
+				log: log,
+				pre: &github.PullRequestEvent{
+					Action: github.PullRequestActionConvertedToDraft,
+					PullRequest: github.PullRequest{
+						Title: "Conformance results for v1.35/coolkube",
+						Draft: true,
+					},
+				},
+			},
+			wantComment: "is a draft",
+		},
+		{
+			name: "draft not a conformance pr",
+			args: args{
+				log: log,
+				pre: &github.PullRequestEvent{
+					Action: github.PullRequestActionOpened,
+					PullRequest: github.PullRequest{
+						Title: "soup recipes for winter",
+						Draft: true,
+					},
+				},
+			},
+			wantNoComments: true,
 		},
 	}
 	for _, tt := range tests {
@@ -1181,6 +1726,7 @@ contact_email_address: "sales@coolkubernetes.com"`,
 						supportingFile = file
 					}
 				}
+				w.Header().Set("Content-Type", "text/html")
 				w.WriteHeader(http.StatusOK)
 				_, err := w.Write([]byte(supportingFile.Contents))
 				if err != nil {
@@ -1212,6 +1758,24 @@ contact_email_address: "sales@coolkubernetes.com"`,
 			if err := HandlePullRequestEvent(tt.args.log, ghc, tt.args.pre); (err != nil) != tt.wantErr {
 				t.Errorf("HandlePullRequestEvent() error = %v, wantErr %v", err, tt.wantErr)
 			}
+			comments := ghc.GetPopulatedPullRequests()[0].Comments
+			if tt.wantNoComments && len(comments) != 0 {
+				t.Errorf("HandlePullRequestEvent() unexpected comments = %v", comments)
+			}
+			if got := ghc.GetPopulatedPullRequests()[0].HeadRefOID; tt.wantStatusSHA != "" && got != tt.wantStatusSHA {
+				t.Errorf("HandlePullRequestEvent() status sha = %v, want %v", got, tt.wantStatusSHA)
+			}
+			if tt.wantComment != "" {
+				found := false
+				for _, c := range comments {
+					if strings.Contains(c.Body, tt.wantComment) {
+						found = true
+					}
+				}
+				if !found {
+					t.Errorf("HandlePullRequestEvent() comments = %v, want comment containing %v", comments, tt.wantComment)
+				}
+			}
 		})
 	}
 }
@@ -1227,12 +1791,14 @@ func TestHandleIssueCommentEvent(t *testing.T) {
 		pullRequestQuery *suite.PullRequestQuery
 		supportingFiles  []*suite.PullRequestFile
 		wantErr          bool
+		wantComment      string
+		wantNoComments   bool
 	}{
 		{
 			name: "basic",
 			pullRequestQuery: &suite.PullRequestQuery{
 				Number: githubql.Int(12345),
-				Title:  githubql.String("Conformance results for v1.33/coolkube"),
+				Title:  githubql.String("Conformance results for v1.35/coolkube"),
 				Commits: struct {
 					Nodes []struct {
 						Commit struct {
@@ -1291,23 +1857,17 @@ func TestHandleIssueCommentEvent(t *testing.T) {
 			},
 			supportingFiles: []*suite.PullRequestFile{
 				{
-					Name:     "v1.33/coolkube/README.md",
+					Name:     "v1.35/coolkube/README.md",
 					BaseName: "README.md",
-					Contents: `# coolkube
-> the coolest Kubernetes distribution
-
-## Generating conformance results
-
-1. create a coolkube cluster
-2. sonobuoy run --wait && sonobuoy results "$(sonobuoy retrieve)" && sonobuoy delete --wait`,
-					BlobURL: "README.md",
+					Contents: testReadmeCoolkubeV135README,
+					BlobURL:  "README.md",
 				},
 				{
-					Name:     "v1.33/coolkube/PRODUCT.yaml",
+					Name:     "v1.35/coolkube/PRODUCT.yaml",
 					BaseName: "PRODUCT.yaml",
 					Contents: `vendor: "cool"
 name: "coolkube"
-version: "v1.33"
+version: "v1.35"
 type: "distribution"
 description: "it's just all-round cool and probably the best k8s, idk"
 website_url: "website_url"
@@ -1316,15 +1876,15 @@ contact_email_address: "sales@coolkubernetes.com"`,
 					BlobURL: "PRODUCT.yaml",
 				},
 				{
-					Name:     "v1.33/coolkube/e2e.log",
+					Name:     "v1.35/coolkube/e2e.log",
 					BaseName: "e2e.log",
 					Contents: "",
 					BlobURL:  "e2e.log",
 				},
 				{
-					Name:     "v1.33/coolkube/junit_01.xml",
+					Name:     "v1.35/coolkube/junit_01.xml",
 					BaseName: "junit_01.xml",
-					Contents: testGetJunitSubmittedConformanceTestsCoolkubeV133Junit_01xml,
+					Contents: testGetJunitSubmittedConformanceTestsCoolkubeV135Junit_01xml,
 					BlobURL:  "junit_01.xml",
 				},
 			},
@@ -1336,7 +1896,10 @@ contact_email_address: "sales@coolkubernetes.com"`,
 						PullRequest: &struct{}{},
 						Number:      12345,
 					},
-					Comment: github.IssueComment{},
+					Comment: github.IssueComment{
+						Body: "/verify-conformance recheck",
+						User: github.User{Login: "someone"},
+					},
 					Repo: github.Repo{
 						Owner: github.User{
 							Login: "cncf",
@@ -1346,6 +1909,111 @@ contact_email_address: "sales@coolkubernetes.com"`,
 				},
 			},
 		},
+		{
+			name: "help",
+			pullRequestQuery: &suite.PullRequestQuery{
+				Number: githubql.Int(12345),
+			},
+			args: args{
+				log: log,
+				ice: &github.IssueCommentEvent{
+					Action: github.IssueCommentActionCreated,
+					Issue: github.Issue{
+						PullRequest: &struct{}{},
+						Number:      12345,
+					},
+					Comment: github.IssueComment{
+						Body: "thanks!\n/verify-conformance help",
+						User: github.User{Login: "someone"},
+					},
+				},
+			},
+			wantComment: "@someone: The following commands are available",
+		},
+		{
+			name: "explain",
+			pullRequestQuery: &suite.PullRequestQuery{
+				Number: githubql.Int(12345),
+			},
+			args: args{
+				log: log,
+				ice: &github.IssueCommentEvent{
+					Action: github.IssueCommentActionCreated,
+					Issue: github.Issue{
+						PullRequest: &struct{}{},
+						Number:      12345,
+					},
+					Comment: github.IssueComment{
+						Body: "/verify-conformance explain all tests pass",
+						User: github.User{Login: "someone"},
+					},
+				},
+			},
+			wantComment: "Then the tests pass and are successful",
+		},
+		{
+			name: "comment without command",
+			pullRequestQuery: &suite.PullRequestQuery{
+				Number: githubql.Int(12345),
+			},
+			args: args{
+				log: log,
+				ice: &github.IssueCommentEvent{
+					Action: github.IssueCommentActionCreated,
+					Issue: github.Issue{
+						PullRequest: &struct{}{},
+						Number:      12345,
+					},
+					Comment: github.IssueComment{
+						Body: "please verify-conformance recheck",
+						User: github.User{Login: "someone"},
+					},
+				},
+			},
+			wantNoComments: true,
+		},
+		{
+			name: "comment from the bot",
+			pullRequestQuery: &suite.PullRequestQuery{
+				Number: githubql.Int(12345),
+			},
+			args: args{
+				log: log,
+				ice: &github.IssueCommentEvent{
+					Action: github.IssueCommentActionCreated,
+					Issue: github.Issue{
+						PullRequest: &struct{}{},
+						Number:      12345,
+					},
+					Comment: github.IssueComment{
+						Body: "/verify-conformance help",
+						User: github.User{Login: "cncfci(bot)"},
+					},
+				},
+			},
+			wantNoComments: true,
+		},
+		{
+			name: "edited comment",
+			pullRequestQuery: &suite.PullRequestQuery{
+				Number: githubql.Int(12345),
+			},
+			args: args{
+				log: log,
+				ice: &github.IssueCommentEvent{
+					Action: github.IssueCommentActionEdited,
+					Issue: github.Issue{
+						PullRequest: &struct{}{},
+						Number:      12345,
+					},
+					Comment: github.IssueComment{
+						Body: "/verify-conformance help",
+						User: github.User{Login: "someone"},
+					},
+				},
+			},
+			wantNoComments: true,
+		},
 		{
 			name: "not a pr",
 			args: args{
@@ -1381,6 +2049,7 @@ contact_email_address: "sales@coolkubernetes.com"`,
 						supportingFile = file
 					}
 				}
+				w.Header().Set("Content-Type", "text/html")
 				w.WriteHeader(http.StatusOK)
 				_, err := w.Write([]byte(supportingFile.Contents))
 				if err != nil {
@@ -1412,6 +2081,24 @@ contact_email_address: "sales@coolkubernetes.com"`,
 			if err := HandleIssueCommentEvent(tt.args.log, ghc, tt.args.ice); (err != nil) != tt.wantErr {
 				t.Errorf("HandleIssueCommentEvent() error = %v, wantErr %v", err, tt.wantErr)
 			}
+			if tt.pullRequestQuery == nil {
+				return
+			}
+			comments := ghc.GetPopulatedPullRequests()[0].Comments
+			if tt.wantNoComments && len(comments) != 0 {
+				t.Errorf("HandleIssueCommentEvent() unexpected comments = %v", comments)
+			}
+			if tt.wantComment != "" {
+				found := false
+				for _, c := range comments {
+					if strings.Contains(c.Body, tt.wantComment) {
+						found = true
+					}
+				}
+				if !found {
+					t.Errorf("HandleIssueCommentEvent() comments = %v, want comment containing %v", comments, tt.wantComment)
+				}
+			}
 		})
 	}
 }
@@ -1433,7 +2120,7 @@ func TestHandleAll(t *testing.T) {
 				{
 					PullRequestQuery: &suite.PullRequestQuery{
 						Number: githubql.Int(12345),
-						Title:  githubql.String("Conformance results for v1.33/coolkube"),
+						Title:  githubql.String("Conformance results for v1.35/coolkube"),
 						Commits: struct {
 							Nodes []struct {
 								Commit struct {
@@ -1492,23 +2179,17 @@ func TestHandleAll(t *testing.T) {
 					},
 					SupportingFiles: []*suite.PullRequestFile{
 						{
-							Name:     "v1.33/coolkube/README.md",
+							Name:     "v1.35/coolkube/README.md",
 							BaseName: "README.md",
-							Contents: `# coolkube
-> the coolest Kubernetes distribution
-
-## Generating conformance results
-
-1. create a coolkube cluster
-2. sonobuoy run --wait && sonobuoy results "$(sonobuoy retrieve)" && sonobuoy delete --wait`,
-							BlobURL: "README.md",
+							Contents: testReadmeCoolkubeV135README,
+							BlobURL:  "README.md",
 						},
 						{
-							Name:     "v1.33/coolkube/PRODUCT.yaml",
+							Name:     "v1.35/coolkube/PRODUCT.yaml",
 							BaseName: "PRODUCT.yaml",
 							Contents: `vendor: "cool"
 name: "coolkube"
-version: "v1.33"
+version: "v1.35"
 type: "distribution"
 description: "it's just all-round cool and probably the best k8s, idk"
 website_url: "website_url"
@@ -1517,15 +2198,15 @@ contact_email_address: "sales@coolkubernetes.com"`,
 							BlobURL: "PRODUCT.yaml",
 						},
 						{
-							Name:     "v1.33/coolkube/e2e.log",
+							Name:     "v1.35/coolkube/e2e.log",
 							BaseName: "e2e.log",
 							Contents: "",
 							BlobURL:  "e2e.log",
 						},
 						{
-							Name:     "v1.33/coolkube/junit_01.xml",
+							Name:     "v1.35/coolkube/junit_01.xml",
 							BaseName: "junit_01.xml",
-							Contents: testGetJunitSubmittedConformanceTestsCoolkubeV133Junit_01xml,
+							Contents: testGetJunitSubmittedConformanceTestsCoolkubeV135Junit_01xml,
 							BlobURL:  "junit_01.xml",
 						},
 					},
@@ -1568,6 +2249,7 @@ contact_email_address: "sales@coolkubernetes.com"`,
 						}
 					}
 				}
+				w.Header().Set("Content-Type", "text/html")
 				w.WriteHeader(http.StatusOK)
 				_, err := w.Write([]byte(supportingFile.Contents))
 				if err != nil {
@@ -1624,7 +2306,39 @@ func TestNewGitHubPullRequestForPullRequestQuery(t *testing.T) {
 		args args
 		want *github.PullRequest
 	}{
-		// TODO: Add test cases.
+		{
+			name: "basic",
+			args: args{
+				orgName:  "cncf",
+				repoName: "k8s-conformance",
+				number:   12345,
+				pr: &suite.PullRequestQuery{
+					Title:      "Conformance results for v1.35/coolkube",
+					HeadRefOID: "abc123",
+					Author: struct {
+						Login githubql.String
+					}{
+						Login: "example",
+					},
+				},
+			},
+			want: &github.PullRequest{
+				Title:  "Conformance results for v1.35/coolkube",
+				Number: 12345,
+				User: github.User{
+					Login: "example",
+				},
+				Head: github.PullRequestBranch{
+					SHA: "abc123",
+				},
+				Base: github.PullRequestBranch{
+					Repo: github.Repo{
+						Owner: github.User{Login: "cncf"},
+						Name:  "k8s-conformance",
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1669,30 +2383,64 @@ func Test_updateLabels(t *testing.T) {
 	}
 }
 
-func Test_updateComments(t *testing.T) {
-	type args struct {
-		log     *logrus.Entry
-		ghc     githubClient
-		pr      *suite.PullRequestQuery
-		prSuite *suite.PRSuite
-		comment string
-	}
+func Test_titleChanged(t *testing.T) {
 	tests := []struct {
 		name    string
-		args    args
-		wantErr bool
+		changes string
+		want    bool
 	}{
-		// TODO: Add test cases.
+		{
+			name:    "title changed",
+			changes: `{"title":{"from":"Conformance results for v1.34/coolkube"}}`,
+			want:    true,
+		},
+		{
+			name:    "title unchanged",
+			changes: `{"title":{"from":"Conformance results for v1.35/coolkube"}}`,
+		},
+		{
+			name:    "body changed",
+			changes: `{"body":{"from":"hello"}}`,
+		},
+		{
+			name:    "invalid changes",
+			changes: `{`,
+		},
+		{
+			name: "no changes",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if err := updateComments(tt.args.log, tt.args.ghc, tt.args.pr, tt.args.prSuite, tt.args.comment); (err != nil) != tt.wantErr {
-				t.Errorf("updateComments() error = %v, wantErr %v", err, tt.wantErr)
+			pre := &github.PullRequestEvent{
+				Action:  github.PullRequestActionEdited,
+				Changes: []byte(tt.changes),
+				PullRequest: github.PullRequest{
+					Title: "Conformance results for v1.35/coolkube",
+				},
+			}
+			if got := titleChanged(pre); got != tt.want {
+				t.Errorf("titleChanged() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
 
+func Test_labelIsManagedByPlugin(t *testing.T) {
+	for label, want := range map[string]bool{
+		"not-verifiable":                true,
+		"release-v1.35":                 true,
+		"tests-verified-v1.35":          true,
+		"missing-file-e2e.log":          true,
+		"lgtm":                          false,
+		"do-not-merge/work-in-progress": false,
+	} {
+		if got := labelIsManagedByPlugin(label); got != want {
+			t.Errorf("labelIsManagedByPlugin(%v) = %v, want %v", label, got, want)
+		}
+	}
+}
+
 func Test_isConformancePR(t *testing.T) {
 	type args struct {
 		pr *suite.PullRequestQuery
@@ -1706,7 +2454,7 @@ func Test_isConformancePR(t *testing.T) {
 			name: "basic",
 			args: args{
 				pr: &suite.PullRequestQuery{
-					Title: "Conformance results for v1.33/coolkube",
+					Title: "Conformance results for v1.35/coolkube",
 				},
 			},
 			want: true,
@@ -1760,7 +2508,7 @@ func Test_updateStatus(t *testing.T) {
 				log: log,
 				pr: &suite.PullRequestQuery{
 					Number:     githubql.Int(12345),
-					Title:      githubql.String("Conformance results for v1.33/coolkube"),
+					Title:      githubql.String("Conformance results for v1.35/coolkube"),
 					HeadRefOID: "12345678",
 					Commits: struct {
 						Nodes []struct {
@@ -1838,6 +2586,7 @@ func Test_updateStatus(t *testing.T) {
 						supportingFile = file
 					}
 				}
+				w.Header().Set("Content-Type", "text/html")
 				w.WriteHeader(http.StatusOK)
 				_, err := w.Write([]byte(supportingFile.Contents))
 				if err != nil {
@@ -1878,3 +2627,58 @@ func Test_updateStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleReadyToDraft(t *testing.T) {
+	defer func(useChecksAPI bool) { UseChecksAPI = useChecksAPI }(UseChecksAPI)
+
+	for _, useChecksAPI := range []bool{false, true} {
+		t.Run(fmt.Sprintf("checks api %v", useChecksAPI), func(t *testing.T) {
+			UseChecksAPI = useChecksAPI
+			pr := &suite.PullRequestQuery{
+				Number:     githubql.Int(12345),
+				Title:      githubql.String("Conformance results for v1.35/coolkube"),
+				HeadRefOID: "abc123",
+				IsDraft:    true,
+			}
+			pr.Repository.Name = "k8s-conformance"
+			pr.Repository.Owner.Login = "cncf"
+			// the head commit passed before the PR was converted to a draft
+			pr.Commits.Nodes = make([]struct {
+				Commit struct {
+					Oid    githubql.String
+					Status struct {
+						Contexts []struct {
+							Context githubql.String
+							State   githubql.String
+						}
+					}
+				}
+			}, 1)
+			pr.Commits.Nodes[0].Commit.Oid = "abc123"
+			pr.Commits.Nodes[0].Commit.Status.Contexts = []struct {
+				Context githubql.String
+				State   githubql.String
+			}{{Context: "verify-conformance", State: githubql.String(githubql.StatusStateSuccess)}}
+			ghc := NewFakeGitHubClient([]*prContext{{
+				PullRequestQuery: pr,
+				HeadRefOID:       "abc123",
+				Status:           github.Status{Context: "verify-conformance", State: "success"},
+				CheckRuns:        []github.CheckRun{newCheckRun("abc123", &suite.Report{State: "success"})},
+			}})
+
+			if err := handle(log, ghc, pr); err != nil {
+				t.Fatalf("error: unexpected error: %v", err)
+			}
+			prc := ghc.GetPopulatedPullRequests()[0]
+			if useChecksAPI {
+				if len(prc.CheckRuns) != 1 || prc.CheckRuns[0].Conclusion != "neutral" || prc.CheckRuns[0].Output.Title != draftResultDescription {
+					t.Fatalf("error: expected the check run to be neutral; got = %+v", prc.CheckRuns)
+				}
+				return
+			}
+			if prc.Status.State != "pending" || prc.Status.Description != draftResultDescription {
+				t.Fatalf("error: expected the status to be pending; got = %+v", prc.Status)
+			}
+		})
+	}
+}
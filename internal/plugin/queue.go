@@ -0,0 +1,289 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/verify-conformance/internal/suite"
+)
+
+const (
+	// DefaultQueueWorkers is the number of PRs checked at the same time by default
+	DefaultQueueWorkers = 4
+
+	queueMaxRetries = 5
+	queueBaseDelay  = 5 * time.Second
+	queueMaxDelay   = 5 * time.Minute
+)
+
+var errQueueShutDown = fmt.Errorf("work queue is shutting down")
+
+// PRKey identifies a PR in the work queue
+type PRKey struct {
+	Org    string
+	Repo   string
+	Number int
+}
+
+// String returns the key in the form org/repo#number
+func (k PRKey) String() string {
+	return fmt.Sprintf("%v/%v#%v", k.Org, k.Repo, k.Number)
+}
+
+type queueItem struct {
+	// queued is set while the key is waiting to be processed, including while backing off
+	queued     bool
+	processing bool
+	retries    int
+	// waiters are notified with the result of the next run of the key
+	waiters []chan error
+	// pr is the PR as found by a search, which is checked instead of fetching it again,
+	// unless the PR is added again without it
+	pr *suite.PullRequestQuery
+}
+
+// Queue checks PRs on a pool of workers.
+// Adding a PR that is already waiting is collapsed into the waiting item,
+// a PR is never checked by two workers at once and
+// failed checks are retried with exponential backoff.
+type Queue struct {
+	log        *logrus.Entry
+	workers    int
+	maxRetries int
+	baseDelay  time.Duration
+	maxDelay   time.Duration
+	process    func(log *logrus.Entry, key PRKey, pr *suite.PullRequestQuery) error
+
+	mu           sync.Mutex
+	cond         *sync.Cond
+	pending      []PRKey
+	items        map[PRKey]*queueItem
+	shuttingDown bool
+}
+
+// NewQueue returns a Queue fetching and checking PRs on the given number of workers.
+func NewQueue(log *logrus.Entry, ghc githubClient, workers int) *Queue {
+	q := &Queue{
+		log:        log,
+		workers:    workers,
+		maxRetries: queueMaxRetries,
+		baseDelay:  queueBaseDelay,
+		maxDelay:   queueMaxDelay,
+		process: func(log *logrus.Entry, key PRKey, pr *suite.PullRequestQuery) error {
+			return checkPR(log, ghc, key, pr)
+		},
+		items: map[PRKey]*queueItem{},
+	}
+	q.cond = sync.NewCond(&q.mu)
+	return q
+}
+
+// Add queues the PR to be checked, unless it is already waiting.
+// The returned channel receives the result of the check, once it has passed or run out of retries.
+func (q *Queue) Add(key PRKey) <-chan error {
+	return q.add(key, nil)
+}
+
+// AddPR queues the PR as found by a search to be checked, like Add, without fetching it again
+func (q *Queue) AddPR(pr *suite.PullRequestQuery) <-chan error {
+	return q.add(PRKey{
+		Org:    string(pr.Repository.Owner.Login),
+		Repo:   string(pr.Repository.Name),
+		Number: int(pr.Number),
+	}, pr)
+}
+
+func (q *Queue) add(key PRKey, pr *suite.PullRequestQuery) <-chan error {
+	result := make(chan error, 1)
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.shuttingDown {
+		result <- errQueueShutDown
+		return result
+	}
+	item, ok := q.items[key]
+	if !ok {
+		item = &queueItem{}
+		q.items[key] = item
+	}
+	item.waiters = append(item.waiters, result)
+	// the PR may have changed since it was found when it is added again without it, such as from a webhook
+	if !item.queued || pr == nil {
+		item.pr = pr
+	}
+	if item.queued {
+		q.log.WithField("pr", key.String()).Debug("PR is already queued.")
+		return result
+	}
+	item.queued = true
+	// a PR being processed is queued again once it is done
+	if !item.processing {
+		q.pending = append(q.pending, key)
+		q.cond.Signal()
+	}
+	return result
+}
+
+// Len returns the number of PRs waiting to be checked, including those backing off.
+func (q *Queue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	n := 0
+	for _, item := range q.items {
+		if item.queued {
+			n++
+		}
+	}
+	return n
+}
+
+// Run starts the workers and stops them once ctx is done,
+// waiting for the PRs being checked before returning.
+func (q *Queue) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for i := 0; i < q.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for q.processNext() {
+			}
+		}()
+	}
+	<-ctx.Done()
+
+	q.mu.Lock()
+	q.shuttingDown = true
+	q.cond.Broadcast()
+	q.mu.Unlock()
+	wg.Wait()
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for key, item := range q.items {
+		notify(item.waiters, errQueueShutDown)
+		delete(q.items, key)
+	}
+	q.pending = nil
+}
+
+// processNext checks the next PR, returning false once the queue is shutting down
+func (q *Queue) processNext() bool {
+	key, pr, waiters, ok := q.get()
+	if !ok {
+		return false
+	}
+	log := q.log.WithField("pr", key.String())
+	err := q.process(log, key, pr)
+	q.done(log, key, waiters, err)
+	return true
+}
+
+func (q *Queue) get() (key PRKey, pr *suite.PullRequestQuery, waiters []chan error, ok bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for len(q.pending) == 0 && !q.shuttingDown {
+		q.cond.Wait()
+	}
+	if q.shuttingDown {
+		return PRKey{}, nil, nil, false
+	}
+	key, q.pending = q.pending[0], q.pending[1:]
+	item := q.items[key]
+	item.queued = false
+	item.processing = true
+	waiters, item.waiters = item.waiters, nil
+	// retries fetch the PR again
+	pr, item.pr = item.pr, nil
+	return key, pr, waiters, true
+}
+
+func (q *Queue) done(log *logrus.Entry, key PRKey, waiters []chan error, err error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	item := q.items[key]
+	item.processing = false
+	if err != nil && item.retries < q.maxRetries && !errors.Is(err, errUnableToProcess) {
+		item.retries++
+		item.waiters = append(waiters, item.waiters...)
+		if item.queued {
+			// added again while being processed, so retry straight away
+			q.pending = append(q.pending, key)
+			q.cond.Signal()
+			return
+		}
+		item.queued = true
+		delay := backoff(item.retries, q.baseDelay, q.maxDelay)
+		log.WithError(err).Infof("Error checking PR, retrying in %v (%v/%v).", delay, item.retries, q.maxRetries)
+		time.AfterFunc(delay, func() {
+			q.requeue(key)
+		})
+		return
+	}
+	switch {
+	case errors.Is(err, errUnableToProcess):
+		// the PR has been told why it can't be checked, which is routine for some submissions
+		log.WithError(err).Info("PR is unable to be processed.")
+	case err != nil:
+		log.WithError(err).Errorf("Error checking PR, not retrying after %v retries.", item.retries)
+	}
+	item.retries = 0
+	notify(waiters, err)
+	if item.queued {
+		q.pending = append(q.pending, key)
+		q.cond.Signal()
+		return
+	}
+	delete(q.items, key)
+}
+
+// requeue queues a PR again once it has backed off
+func (q *Queue) requeue(key PRKey) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.shuttingDown {
+		return
+	}
+	if item, ok := q.items[key]; ok && item.queued && !item.processing {
+		q.pending = append(q.pending, key)
+		q.cond.Signal()
+	}
+}
+
+func notify(waiters []chan error, err error) {
+	for _, w := range waiters {
+		w <- err
+	}
+}
+
+// backoff returns the delay before a retry, doubling from base with each retry up to max
+func backoff(retries int, base time.Duration, max time.Duration) time.Duration {
+	delay := base
+	for i := 1; i < retries && delay < max; i++ {
+		delay *= 2
+	}
+	if delay > max {
+		return max
+	}
+	return delay
+}
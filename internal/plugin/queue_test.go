@@ -0,0 +1,329 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/verify-conformance/internal/suite"
+)
+
+// newTestQueue returns a running queue processing with process, which is stopped at the end of the test
+func newTestQueue(t *testing.T, workers int, process func(*logrus.Entry, PRKey) error) *Queue {
+	q := NewQueue(log, NewFakeGitHubClient(nil), workers)
+	q.baseDelay = time.Millisecond
+	q.maxDelay = 10 * time.Millisecond
+	q.maxRetries = 2
+	q.process = func(log *logrus.Entry, key PRKey, _ *suite.PullRequestQuery) error {
+		return process(log, key)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		q.Run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-stopped
+	})
+	return q
+}
+
+func waitForResult(t *testing.T, result <-chan error) error {
+	select {
+	case err := <-result:
+		return err
+	case <-time.After(10 * time.Second):
+		t.Fatalf("error: timed out waiting for result")
+	}
+	return nil
+}
+
+func TestPRKeyString(t *testing.T) {
+	if want, got := "cncf/k8s-conformance#12345", (PRKey{Org: "cncf", Repo: "k8s-conformance", Number: 12345}).String(); want != got {
+		t.Fatalf("unexpected key: want = %v; got = %v", want, got)
+	}
+}
+
+func TestQueueAddCollapsesDuplicates(t *testing.T) {
+	key := PRKey{Org: "cncf", Repo: "k8s-conformance", Number: 1}
+	q := NewQueue(log, NewFakeGitHubClient(nil), 1)
+	var calls atomic.Int32
+	q.process = func(*logrus.Entry, PRKey, *suite.PullRequestQuery) error {
+		calls.Add(1)
+		return nil
+	}
+	results := []<-chan error{q.Add(key), q.Add(key), q.Add(key)}
+	if got := q.Len(); got != 1 {
+		t.Fatalf("error: expected one queued PR; got = %v", got)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go q.Run(ctx)
+	for _, result := range results {
+		if err := waitForResult(t, result); err != nil {
+			t.Fatalf("error: unexpected error: %v", err)
+		}
+	}
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("error: expected PR to be checked once; got = %v", got)
+	}
+}
+
+func TestQueueNeverProcessesAPRConcurrently(t *testing.T) {
+	key := PRKey{Org: "cncf", Repo: "k8s-conformance", Number: 1}
+	started := make(chan struct{}, 2)
+	release := make(chan struct{})
+	var running, maxRunning, calls atomic.Int32
+	q := newTestQueue(t, 4, func(*logrus.Entry, PRKey) error {
+		n := running.Add(1)
+		defer running.Add(-1)
+		if n > maxRunning.Load() {
+			maxRunning.Store(n)
+		}
+		calls.Add(1)
+		started <- struct{}{}
+		<-release
+		return nil
+	})
+
+	first := q.Add(key)
+	<-started
+	// added while being processed, so it must wait for the first check
+	second := q.Add(key)
+	third := q.Add(key)
+	select {
+	case <-started:
+		t.Fatalf("error: PR was checked twice at the same time")
+	case <-time.After(100 * time.Millisecond):
+	}
+	close(release)
+	for _, result := range []<-chan error{first, second, third} {
+		if err := waitForResult(t, result); err != nil {
+			t.Fatalf("error: unexpected error: %v", err)
+		}
+	}
+	if got := calls.Load(); got != 2 {
+		t.Fatalf("error: expected PR to be checked twice; got = %v", got)
+	}
+	if got := maxRunning.Load(); got != 1 {
+		t.Fatalf("error: expected at most one check of the PR at a time; got = %v", got)
+	}
+}
+
+func TestQueueProcessesPRsOnWorkers(t *testing.T) {
+	workers := 3
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	q := newTestQueue(t, workers, func(*logrus.Entry, PRKey) error {
+		// every worker must be busy at once for any check to finish
+		wg.Done()
+		wg.Wait()
+		return nil
+	})
+	results := []<-chan error{}
+	for i := range workers {
+		results = append(results, q.Add(PRKey{Org: "cncf", Repo: "k8s-conformance", Number: i}))
+	}
+	for _, result := range results {
+		if err := waitForResult(t, result); err != nil {
+			t.Fatalf("error: unexpected error: %v", err)
+		}
+	}
+}
+
+func TestQueueRetries(t *testing.T) {
+	tests := []struct {
+		name      string
+		failures  int32
+		err       error
+		wantCalls int32
+		wantErr   bool
+	}{
+		{
+			name:      "passes first time",
+			wantCalls: 1,
+		},
+		{
+			name:      "passes after retrying",
+			failures:  2,
+			err:       fmt.Errorf("rate limited"),
+			wantCalls: 3,
+		},
+		{
+			name:      "runs out of retries",
+			failures:  10,
+			err:       fmt.Errorf("rate limited"),
+			wantCalls: 3,
+			wantErr:   true,
+		},
+		{
+			name:      "unable to process is not retried",
+			failures:  10,
+			err:       fmt.Errorf("%w as it is missing for release v1.99", errUnableToProcess),
+			wantCalls: 1,
+			wantErr:   true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls atomic.Int32
+			q := newTestQueue(t, 1, func(*logrus.Entry, PRKey) error {
+				if calls.Add(1) <= tc.failures {
+					return tc.err
+				}
+				return nil
+			})
+			err := waitForResult(t, q.Add(PRKey{Org: "cncf", Repo: "k8s-conformance", Number: 1}))
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("error: unexpected result: %v", err)
+			}
+			if got := calls.Load(); got != tc.wantCalls {
+				t.Fatalf("error: unexpected number of checks: want = %v; got = %v", tc.wantCalls, got)
+			}
+			if got := q.Len(); got != 0 {
+				t.Fatalf("error: expected queue to be empty; got = %v", got)
+			}
+		})
+	}
+}
+
+// levelsHook records the level of each log entry
+type levelsHook struct {
+	mu     sync.Mutex
+	levels []logrus.Level
+}
+
+func (h *levelsHook) Levels() []logrus.Level { return logrus.AllLevels }
+
+func (h *levelsHook) Fire(entry *logrus.Entry) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.levels = append(h.levels, entry.Level)
+	return nil
+}
+
+func TestQueueLogsUnableToProcessAsInfo(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	hook := &levelsHook{}
+	logger.AddHook(hook)
+	q := newTestQueue(t, 1, func(*logrus.Entry, PRKey) error {
+		return fmt.Errorf("%w as it is missing for release v1.99", errUnableToProcess)
+	})
+	q.log = logrus.NewEntry(logger)
+	if err := waitForResult(t, q.Add(PRKey{Org: "cncf", Repo: "k8s-conformance", Number: 1})); !errors.Is(err, errUnableToProcess) {
+		t.Fatalf("error: unexpected result: %v", err)
+	}
+	hook.mu.Lock()
+	defer hook.mu.Unlock()
+	if len(hook.levels) != 1 || hook.levels[0] != logrus.InfoLevel {
+		t.Fatalf("error: expected a single info log for a PR unable to be processed; got = %v", hook.levels)
+	}
+}
+
+func TestQueueAddPR(t *testing.T) {
+	pr := &suite.PullRequestQuery{Number: 1, HeadRefOID: "1111111"}
+	pr.Repository.Name = "k8s-conformance"
+	pr.Repository.Owner.Login = "cncf"
+	key := PRKey{Org: "cncf", Repo: "k8s-conformance", Number: 1}
+
+	for _, tc := range []struct {
+		Name       string
+		Add        func(q *Queue) <-chan error
+		ExpectedPR *suite.PullRequestQuery
+	}{
+		{
+			Name:       "found by a search",
+			Add:        func(q *Queue) <-chan error { return q.AddPR(pr) },
+			ExpectedPR: pr,
+		},
+		{
+			Name: "added again without it, such as from a webhook",
+			Add: func(q *Queue) <-chan error {
+				q.AddPR(pr)
+				return q.Add(key)
+			},
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			q := NewQueue(log, NewFakeGitHubClient(nil), 1)
+			var processed []*suite.PullRequestQuery
+			q.process = func(_ *logrus.Entry, _ PRKey, pr *suite.PullRequestQuery) error {
+				processed = append(processed, pr)
+				return nil
+			}
+			result := tc.Add(q)
+			ctx, cancel := context.WithCancel(context.Background())
+			stopped := make(chan struct{})
+			go func() {
+				defer close(stopped)
+				q.Run(ctx)
+			}()
+			if err := waitForResult(t, result); err != nil {
+				t.Fatalf("error: unexpected error: %v", err)
+			}
+			cancel()
+			<-stopped
+			if len(processed) != 1 || processed[0] != tc.ExpectedPR {
+				t.Fatalf("error: expected the PR to be checked once with %v; got = %v", tc.ExpectedPR, processed)
+			}
+		})
+	}
+}
+
+func TestQueueShutdown(t *testing.T) {
+	q := NewQueue(log, NewFakeGitHubClient(nil), 1)
+	q.process = func(*logrus.Entry, PRKey, *suite.PullRequestQuery) error { return nil }
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	q.Run(ctx)
+	if err := waitForResult(t, q.Add(PRKey{Org: "cncf", Repo: "k8s-conformance", Number: 1})); !errors.Is(err, errQueueShutDown) {
+		t.Fatalf("error: expected shutdown error; got = %v", err)
+	}
+}
+
+func Test_backoff(t *testing.T) {
+	tests := []struct {
+		retries int
+		want    time.Duration
+	}{
+		{retries: 1, want: 5 * time.Second},
+		{retries: 2, want: 10 * time.Second},
+		{retries: 3, want: 20 * time.Second},
+		{retries: 7, want: 5 * time.Minute},
+		{retries: 100, want: 5 * time.Minute},
+	}
+	for _, tc := range tests {
+		t.Run(fmt.Sprint(tc.retries), func(t *testing.T) {
+			if got := backoff(tc.retries, queueBaseDelay, queueMaxDelay); got != tc.want {
+				t.Errorf("backoff() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
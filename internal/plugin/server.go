@@ -0,0 +1,249 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	signatureHeader = "X-Hub-Signature-256"
+	signaturePrefix = "sha256="
+	// maxPayloadSize is the largest webhook payload read, being the most GitHub sends
+	maxPayloadSize = 25 << 20
+)
+
+// Server receives GitHub webhooks relayed by Prow and hands the supported
+// events to the plugin handlers.
+type Server struct {
+	tokenGenerator func() []byte
+	ghc            githubClient
+	queue          *Queue
+	log            *logrus.Entry
+
+	mu sync.Mutex
+	// stopping is set once Shutdown is called, after which events are handled before responding
+	stopping bool
+	// events are the events being handled in the background
+	events sync.WaitGroup
+}
+
+// NewServer returns a Server validating payloads with the HMAC secret from tokenGenerator
+// and queueing the PRs to check on q.
+func NewServer(log *logrus.Entry, ghc githubClient, q *Queue, tokenGenerator func() []byte) *Server {
+	return &Server{
+		tokenGenerator: tokenGenerator,
+		ghc:            ghc,
+		queue:          q,
+		log:            log,
+	}
+}
+
+// validatePayload ensures that the sha256 signature of the payload matches one of the secrets
+// for its org or repo in the HMAC secret.
+func validatePayload(payload []byte, sig string, tokenGenerator func() []byte) bool {
+	if !strings.HasPrefix(sig, signaturePrefix) {
+		return false
+	}
+	sb, err := hex.DecodeString(strings.TrimPrefix(sig, signaturePrefix))
+	if err != nil {
+		return false
+	}
+	var event github.GenericEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return false
+	}
+	orgRepo := event.Repo.FullName
+	if orgRepo == "" {
+		orgRepo = event.Org.Login
+	}
+	for _, key := range hmacsForOrgRepo(orgRepo, tokenGenerator()) {
+		mac := hmac.New(sha256.New, key)
+		mac.Write(payload)
+		if hmac.Equal(sb, mac.Sum(nil)) {
+			return true
+		}
+	}
+	return false
+}
+
+// hmacsForOrgRepo returns the keys in the HMAC secret for orgRepo, like Prow does for its own webhooks.
+// The secret is either a map of repos, orgs or '*' to their tokens, only using the most specific level found,
+// or a single token.
+func hmacsForOrgRepo(orgRepo string, secret []byte) [][]byte {
+	tokens := map[string]github.HMACsForRepo{}
+	if err := yaml.Unmarshal(secret, &tokens); err != nil {
+		return [][]byte{bytes.TrimSpace(secret)}
+	}
+	for _, level := range []string{orgRepo, strings.Split(orgRepo, "/")[0], "*"} {
+		forRepo, found := tokens[level]
+		if !found {
+			continue
+		}
+		keys := [][]byte{}
+		for _, token := range forRepo {
+			keys = append(keys, []byte(token.Value))
+		}
+		return keys
+	}
+	return nil
+}
+
+// payloadSignature returns the sha256 signature that matches the payload.
+func payloadSignature(payload []byte, key []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(payload)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// ServeHTTP validates an incoming webhook and handles its event.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	defer func() {
+		if err := r.Body.Close(); err != nil {
+			s.log.WithError(err).Debug("failed to close request body")
+		}
+	}()
+	if r.Method != http.MethodPost {
+		http.Error(w, "405 Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	eventType := r.Header.Get("X-GitHub-Event")
+	if eventType == "" {
+		http.Error(w, "400 Bad Request: Missing X-GitHub-Event Header", http.StatusBadRequest)
+		return
+	}
+	eventGUID := r.Header.Get("X-GitHub-Delivery")
+	if eventGUID == "" {
+		http.Error(w, "400 Bad Request: Missing X-GitHub-Delivery Header", http.StatusBadRequest)
+		return
+	}
+	sig := r.Header.Get(signatureHeader)
+	if sig == "" {
+		http.Error(w, "403 Forbidden: Missing "+signatureHeader, http.StatusForbidden)
+		return
+	}
+	payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxPayloadSize))
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		http.Error(w, "413 Request Entity Too Large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	if err != nil {
+		http.Error(w, "500 Internal Server Error: Failed to read request body", http.StatusInternalServerError)
+		return
+	}
+	if !validatePayload(payload, sig, s.tokenGenerator) {
+		http.Error(w, "403 Forbidden: Invalid "+signatureHeader, http.StatusForbidden)
+		return
+	}
+	if _, err := fmt.Fprint(w, "Event received. Have a nice day."); err != nil {
+		s.log.WithError(err).Debug("failed to write response")
+	}
+	if err := s.handleEvent(eventType, eventGUID, payload); err != nil {
+		s.log.WithError(err).Error("Error parsing event.")
+	}
+}
+
+// handleEvent decodes the payload of the supported event types and handles it in the background,
+// queueing the PRs which need to be checked.
+func (s *Server) handleEvent(eventType, eventGUID string, payload []byte) error {
+	l := s.log.WithFields(logrus.Fields{
+		"event-type":     eventType,
+		github.EventGUID: eventGUID,
+	})
+	switch eventType {
+	case "pull_request":
+		var pre github.PullRequestEvent
+		if err := json.Unmarshal(payload, &pre); err != nil {
+			return err
+		}
+		l = l.WithFields(logrus.Fields{
+			github.OrgLogField:  pre.Repo.Owner.Login,
+			github.RepoLogField: pre.Repo.Name,
+			github.PrLogField:   pre.Number,
+		})
+		s.goHandle(func() {
+			needsCheck, err := pullRequestEventNeedsCheck(s.ghc, &pre)
+			if err != nil {
+				l.WithError(err).Info("Error handling event.")
+				return
+			}
+			if needsCheck {
+				s.queue.Add(PRKey{Org: pre.Repo.Owner.Login, Repo: pre.Repo.Name, Number: pre.Number})
+			}
+		})
+	case "issue_comment":
+		var ice github.IssueCommentEvent
+		if err := json.Unmarshal(payload, &ice); err != nil {
+			return err
+		}
+		l = l.WithFields(logrus.Fields{
+			github.OrgLogField:  ice.Repo.Owner.Login,
+			github.RepoLogField: ice.Repo.Name,
+			github.PrLogField:   ice.Issue.Number,
+		})
+		s.goHandle(func() {
+			if err := handleIssueCommentEvent(l, s.ghc, &ice, func(key PRKey) error {
+				s.queue.Add(key)
+				return nil
+			}); err != nil {
+				l.WithError(err).Info("Error handling event.")
+			}
+		})
+	default:
+		l.Debugf("skipping event of type %q", eventType)
+	}
+	return nil
+}
+
+// goHandle handles an event in the background, or right away once the Server is shutting down
+func (s *Server) goHandle(work func()) {
+	s.mu.Lock()
+	if s.stopping {
+		s.mu.Unlock()
+		work()
+		return
+	}
+	s.events.Add(1)
+	s.mu.Unlock()
+	go func() {
+		defer s.events.Done()
+		work()
+	}()
+}
+
+// Shutdown waits for the events being handled in the background to be done
+func (s *Server) Shutdown() {
+	s.mu.Lock()
+	s.stopping = true
+	s.mu.Unlock()
+	s.events.Wait()
+}
@@ -0,0 +1,250 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func Test_validatePayload(t *testing.T) {
+	secret := []byte("abc123")
+	legacySecret := append(secret, '\n')
+	multiSecret := []byte(`'*':
+  - value: abc123
+    created_at: 2020-10-02T15:00:00Z
+  - value: key2
+    created_at: 2021-10-02T15:00:00Z
+'cncf':
+  - value: org-key
+    created_at: 2021-10-02T15:00:00Z
+'cncf/k8s-conformance':
+  - value: repo-key
+    created_at: 2021-10-02T15:00:00Z
+`)
+	payload := []byte(`{"action":"opened"}`)
+	repoPayload := []byte(`{"action":"opened","repository":{"full_name":"cncf/k8s-conformance"}}`)
+	orgPayload := []byte(`{"action":"opened","repository":{"full_name":"cncf/soup"}}`)
+
+	tests := []struct {
+		name    string
+		secret  []byte
+		payload []byte
+		sig     string
+		want    bool
+	}{
+		{
+			name:   "valid signature",
+			secret: legacySecret,
+			sig:    payloadSignature(payload, secret),
+			want:   true,
+		},
+		{
+			name:   "signature for another secret",
+			secret: legacySecret,
+			sig:    payloadSignature(payload, []byte("def456")),
+			want:   false,
+		},
+		{
+			name:   "sha1 signature",
+			secret: legacySecret,
+			sig:    "sha1=0123456789abcdef",
+			want:   false,
+		},
+		{
+			name:   "not hex",
+			secret: legacySecret,
+			sig:    "sha256=zzz",
+			want:   false,
+		},
+		{
+			name:   "empty",
+			secret: legacySecret,
+			want:   false,
+		},
+		{
+			name:   "global token of many",
+			secret: multiSecret,
+			sig:    payloadSignature(payload, []byte("key2")),
+			want:   true,
+		},
+		{
+			name:    "repo token",
+			secret:  multiSecret,
+			payload: repoPayload,
+			sig:     payloadSignature(repoPayload, []byte("repo-key")),
+			want:    true,
+		},
+		{
+			name:    "global token for a repo with its own token",
+			secret:  multiSecret,
+			payload: repoPayload,
+			sig:     payloadSignature(repoPayload, secret),
+			want:    false,
+		},
+		{
+			name:    "org token",
+			secret:  multiSecret,
+			payload: orgPayload,
+			sig:     payloadSignature(orgPayload, []byte("org-key")),
+			want:    true,
+		},
+		{
+			name:   "signature for a token not in the secret",
+			secret: multiSecret,
+			sig:    payloadSignature(payload, []byte("repo-key")),
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := payload
+			if tt.payload != nil {
+				p = tt.payload
+			}
+			if got := validatePayload(p, tt.sig, func() []byte { return tt.secret }); got != tt.want {
+				t.Errorf("validatePayload() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServerServeHTTP(t *testing.T) {
+	secret := []byte("abc123")
+	payload := []byte(`{"zen":"Keep it logically awesome."}`)
+
+	tests := []struct {
+		name       string
+		method     string
+		headers    map[string]string
+		payload    []byte
+		wantStatus int
+	}{
+		{
+			name:       "wrong method",
+			method:     http.MethodGet,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:       "missing event type",
+			method:     http.MethodPost,
+			headers:    map[string]string{"X-GitHub-Delivery": "1"},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "missing delivery",
+			method:     http.MethodPost,
+			headers:    map[string]string{"X-GitHub-Event": "ping"},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "missing signature",
+			method:     http.MethodPost,
+			headers:    map[string]string{"X-GitHub-Event": "ping", "X-GitHub-Delivery": "1"},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:   "invalid signature",
+			method: http.MethodPost,
+			headers: map[string]string{
+				"X-GitHub-Event":      "ping",
+				"X-GitHub-Delivery":   "1",
+				"X-Hub-Signature-256": payloadSignature(payload, []byte("nope")),
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:   "payload too large",
+			method: http.MethodPost,
+			headers: map[string]string{
+				"X-GitHub-Event":      "ping",
+				"X-GitHub-Delivery":   "1",
+				"X-Hub-Signature-256": payloadSignature(payload, secret),
+			},
+			payload:    bytes.Repeat([]byte(" "), maxPayloadSize+1),
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:   "valid unhandled event",
+			method: http.MethodPost,
+			headers: map[string]string{
+				"X-GitHub-Event":      "ping",
+				"X-GitHub-Delivery":   "1",
+				"X-Hub-Signature-256": payloadSignature(payload, secret),
+			},
+			wantStatus: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewServer(log, NewFakeGitHubClient(nil), NewQueue(log, NewFakeGitHubClient(nil), 1), func() []byte { return secret })
+			body := payload
+			if tt.payload != nil {
+				body = tt.payload
+			}
+			req := httptest.NewRequest(tt.method, "/", bytes.NewReader(body))
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+			s.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Errorf("ServeHTTP() status = %v, want %v", w.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestServerHandleEvent(t *testing.T) {
+	q := NewQueue(log, NewFakeGitHubClient(nil), 1)
+	s := NewServer(log, NewFakeGitHubClient(nil), q, func() []byte { return nil })
+	for _, eventType := range []string{"pull_request", "issue_comment"} {
+		if err := s.handleEvent(eventType, "1", []byte(`not json`)); err == nil {
+			t.Fatalf("error: expected malformed %v payload to fail", eventType)
+		}
+	}
+	if err := s.handleEvent("push", "1", []byte(`not json`)); err != nil {
+		t.Fatalf("error: unexpected error for unhandled event type: %v", err)
+	}
+
+	for _, payload := range []string{
+		`{"action":"closed","number":1,"repository":{"name":"k8s-conformance","owner":{"login":"cncf"}}}`,
+		`{"action":"opened","number":2,"repository":{"name":"k8s-conformance","owner":{"login":"cncf"}}}`,
+		`{"action":"synchronize","number":2,"repository":{"name":"k8s-conformance","owner":{"login":"cncf"}}}`,
+	} {
+		if err := s.handleEvent("pull_request", "1", []byte(payload)); err != nil {
+			t.Fatalf("error: unexpected error handling event: %v", err)
+		}
+	}
+	s.Shutdown()
+	if got := q.Len(); got != 1 {
+		t.Fatalf("error: expected only one PR to be queued; got = %v", got)
+	}
+	if _, ok := q.items[PRKey{Org: "cncf", Repo: "k8s-conformance", Number: 2}]; !ok {
+		t.Fatalf("error: expected cncf/k8s-conformance#2 to be queued; got = %v", q.items)
+	}
+
+	// once shutting down, events are handled before the request is done
+	if err := s.handleEvent("pull_request", "1", []byte(`{"action":"opened","number":3,"repository":{"name":"k8s-conformance","owner":{"login":"cncf"}}}`)); err != nil {
+		t.Fatalf("error: unexpected error handling event: %v", err)
+	}
+	if _, ok := q.items[PRKey{Org: "cncf", Repo: "k8s-conformance", Number: 3}]; !ok {
+		t.Fatalf("error: expected cncf/k8s-conformance#3 to be queued; got = %v", q.items)
+	}
+}
@@ -0,0 +1,327 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package resolver resolves the URLs found in a submission, such as those in PRODUCT.yaml,
+// without trusting them: requests time out, certificates are verified, redirects are
+// followed a limited number of times and private addresses are never dialed.
+package resolver
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const (
+	// DefaultTimeout is how long to wait on a URL, including redirects and reading the body
+	DefaultTimeout = 30 * time.Second
+	// DefaultMaxRedirects is how many redirects are followed before giving up
+	DefaultMaxRedirects = 10
+	// DefaultMaxConcurrentRequestsPerHost is how many requests are made to a single host at once
+	DefaultMaxConcurrentRequestsPerHost = 2
+)
+
+var (
+	// ErrBlockedAddress is returned when a URL resolves to an address which must not be requested
+	ErrBlockedAddress = errors.New("the address is private, loopback or link-local")
+
+	// blockedNetworks are not covered by the checks on net.IP, such as shared address space
+	blockedNetworks = []*net.IPNet{
+		mustParseCIDR("100.64.0.0/10"),
+		mustParseCIDR("192.0.0.0/24"),
+		mustParseCIDR("198.18.0.0/15"),
+	}
+)
+
+func mustParseCIDR(cidr string) *net.IPNet {
+	_, n, err := net.ParseCIDR(cidr)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+// Options configures a Resolver, where zero values are replaced with the defaults
+type Options struct {
+	Timeout                      time.Duration
+	MaxRedirects                 int
+	MaxConcurrentRequestsPerHost int
+	// AllowPrivateAddresses permits requests to private, loopback and link-local addresses,
+	// which is only for pointing a Resolver at a local server in tests
+	AllowPrivateAddresses bool
+	// RootCAs are the certificate authorities to verify against, using the system's when nil
+	RootCAs *x509.CertPool
+}
+
+// Result is the outcome of resolving a URL
+type Result struct {
+	URL string
+	// Method is the method of the request which was answered, GET being used when HEAD is rejected
+	Method      string
+	StatusCode  int
+	ContentType string
+	// Redirects are the URLs redirected to in order, the last being where the response came from
+	Redirects []string
+	// Insecure is why the TLS certificate failed verification, when the URL was only able to be
+	// resolved without verifying it
+	Insecure string
+	// Contents is the body of the response, only read by Fetch
+	Contents []byte
+	// Error is why the URL was unable to be resolved
+	Error string
+}
+
+// Resolver makes requests to untrusted URLs
+type Resolver struct {
+	options           Options
+	transport         *http.Transport
+	insecureTransport *http.Transport
+
+	mu sync.Mutex
+	// hosts are the request slots of the hosts being requested, removed once nothing is waiting for or holding them
+	hosts map[string]*hostSlots
+}
+
+// hostSlots limits the concurrent requests to a host
+type hostSlots struct {
+	slots chan struct{}
+	// users is how many requests are waiting for or holding a slot
+	users int
+}
+
+// New returns a Resolver with options
+func New(options Options) *Resolver {
+	if options.Timeout <= 0 {
+		options.Timeout = DefaultTimeout
+	}
+	if options.MaxRedirects <= 0 {
+		options.MaxRedirects = DefaultMaxRedirects
+	}
+	if options.MaxConcurrentRequestsPerHost <= 0 {
+		options.MaxConcurrentRequestsPerHost = DefaultMaxConcurrentRequestsPerHost
+	}
+	r := &Resolver{
+		options: options,
+		hosts:   map[string]*hostSlots{},
+	}
+	r.transport = r.newTransport(&tls.Config{RootCAs: options.RootCAs})
+	r.insecureTransport = r.newTransport(&tls.Config{InsecureSkipVerify: true})
+	return r
+}
+
+func (r *Resolver) newTransport(tlsConfig *tls.Config) *http.Transport {
+	dialer := &net.Dialer{
+		Timeout: r.options.Timeout,
+		Control: r.control,
+	}
+	return &http.Transport{
+		Proxy:                 nil,
+		DialContext:           dialer.DialContext,
+		TLSClientConfig:       tlsConfig,
+		TLSHandshakeTimeout:   r.options.Timeout,
+		ResponseHeaderTimeout: r.options.Timeout,
+	}
+}
+
+// control refuses to connect to blocked addresses, checked after DNS resolution
+// so that a hostname pointing at a private address is refused too
+func (r *Resolver) control(network, address string, _ syscall.RawConn) error {
+	if r.options.AllowPrivateAddresses {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if IsBlockedIP(net.ParseIP(host)) {
+		return ErrBlockedAddress
+	}
+	return nil
+}
+
+// IsBlockedIP returns whether ip is an address which must not be requested,
+// such as private, loopback, link-local and unspecified addresses
+func IsBlockedIP(ip net.IP) bool {
+	if ip == nil {
+		return true
+	}
+	if ip.IsPrivate() || ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return true
+	}
+	for _, n := range blockedNetworks {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// acquire waits for a free slot for host, returning the func to release it
+func (r *Resolver) acquire(ctx context.Context, host string) (func(), error) {
+	r.mu.Lock()
+	h, found := r.hosts[host]
+	if !found {
+		h = &hostSlots{slots: make(chan struct{}, r.options.MaxConcurrentRequestsPerHost)}
+		r.hosts[host] = h
+	}
+	h.users++
+	r.mu.Unlock()
+	select {
+	case h.slots <- struct{}{}:
+		return func() {
+			<-h.slots
+			r.done(host, h)
+		}, nil
+	case <-ctx.Done():
+		r.done(host, h)
+		return nil, ctx.Err()
+	}
+}
+
+// done forgets the slots of host once the last request for it is done,
+// so that the hosts of every URL ever resolved aren't kept
+func (r *Resolver) done(host string, h *hostSlots) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	h.users--
+	if h.users == 0 {
+		delete(r.hosts, host)
+	}
+}
+
+// Resolve returns the content type of uri using a HEAD request,
+// falling back to a GET request when the server rejects HEAD
+func (r *Resolver) Resolve(ctx context.Context, uri string) *Result {
+	result := r.do(ctx, http.MethodHead, uri, 0)
+	if result.Error == "" && result.ContentType != "" {
+		return result
+	}
+	if result.StatusCode == 0 && result.Error != "" {
+		return result
+	}
+	return r.do(ctx, http.MethodGet, uri, 0)
+}
+
+// Fetch returns the response of a GET request to uri with up to maxSize bytes of the body,
+// where a larger body is an error
+func (r *Resolver) Fetch(ctx context.Context, uri string, maxSize int64) *Result {
+	return r.do(ctx, http.MethodGet, uri, maxSize)
+}
+
+func (r *Resolver) do(ctx context.Context, method, uri string, maxSize int64) *Result {
+	result := &Result{URL: uri, Method: method}
+	u, err := url.ParseRequestURI(uri)
+	if err != nil {
+		result.Error = fmt.Sprintf("it is not a valid URL, %v", err)
+		return result
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		result.Error = fmt.Sprintf("the scheme '%v' is not supported, it must be http or https", u.Scheme)
+		return result
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.options.Timeout)
+	defer cancel()
+	resp, contents, redirects, err := r.request(ctx, r.transport, method, u, maxSize)
+	var certErr *tls.CertificateVerificationError
+	if err != nil && errors.As(err, &certErr) {
+		result.Insecure = certErr.Err.Error()
+		resp, contents, redirects, err = r.request(ctx, r.insecureTransport, method, u, maxSize)
+	}
+	result.Redirects = redirects
+	if err != nil {
+		result.Error = describeError(err)
+		return result
+	}
+	result.Contents = contents
+	result.StatusCode = resp.StatusCode
+	result.ContentType = resp.Header.Get("Content-Type")
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		result.Error = fmt.Sprintf("the server responded with status %v", resp.Status)
+	}
+	return result
+}
+
+// request makes a request following redirects, returning up to maxSize bytes of the body
+// and the URLs redirected to
+func (r *Resolver) request(ctx context.Context, transport http.RoundTripper, method string, u *url.URL, maxSize int64) (resp *http.Response, contents []byte, redirects []string, err error) {
+	release, err := r.acquire(ctx, u.Host)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	defer release()
+
+	client := &http.Client{
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			redirects = append(redirects, req.URL.String())
+			if len(via) > r.options.MaxRedirects {
+				return fmt.Errorf("stopped after %v redirects", r.options.MaxRedirects)
+			}
+			if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+				return fmt.Errorf("redirected to the unsupported scheme '%v'", req.URL.Scheme)
+			}
+			return nil
+		},
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), nil)
+	if err != nil {
+		return nil, nil, redirects, err
+	}
+	resp, err = client.Do(req)
+	if err != nil {
+		return nil, nil, redirects, err
+	}
+	body := resp.Body
+	defer func() {
+		_ = body.Close()
+	}()
+	if maxSize > 0 && resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		contents, err = io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+		if err != nil {
+			return nil, nil, redirects, err
+		}
+		if int64(len(contents)) > maxSize {
+			return nil, nil, redirects, fmt.Errorf("it is larger than the limit of %v bytes", maxSize)
+		}
+	}
+	return resp, contents, redirects, nil
+}
+
+// describeError returns the cause of a failed request without the wrapping of net/http
+func describeError(err error) string {
+	if errors.Is(err, ErrBlockedAddress) {
+		return ErrBlockedAddress.Error()
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return "the request timed out"
+	}
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Err.Error()
+	}
+	return err.Error()
+}
@@ -0,0 +1,296 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"context"
+	"crypto/x509"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestServer() *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/page", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte("<html>cool</html>"))
+	})
+	mux.HandleFunc("/no-head", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte("<html>cool</html>"))
+	})
+	mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/moved", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/moved", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/page", http.StatusFound)
+	})
+	mux.HandleFunc("/loop", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/loop", http.StatusFound)
+	})
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.Header().Set("Content-Type", "text/html")
+	})
+	mux.HandleFunc("/large", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte(strings.Repeat("a", 11)))
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestResolve(t *testing.T) {
+	svr := newTestServer()
+	defer svr.Close()
+
+	type testCase struct {
+		Name     string
+		Options  Options
+		URL      string
+		Expected *Result
+	}
+
+	for _, tc := range []testCase{
+		{
+			Name:    "html page",
+			Options: Options{AllowPrivateAddresses: true},
+			URL:     svr.URL + "/page",
+			Expected: &Result{
+				URL:         svr.URL + "/page",
+				Method:      http.MethodHead,
+				StatusCode:  http.StatusOK,
+				ContentType: "text/html; charset=utf-8",
+			},
+		},
+		{
+			Name:    "HEAD rejected",
+			Options: Options{AllowPrivateAddresses: true},
+			URL:     svr.URL + "/no-head",
+			Expected: &Result{
+				URL:         svr.URL + "/no-head",
+				Method:      http.MethodGet,
+				StatusCode:  http.StatusOK,
+				ContentType: "text/html",
+			},
+		},
+		{
+			Name:    "redirects",
+			Options: Options{AllowPrivateAddresses: true},
+			URL:     svr.URL + "/redirect",
+			Expected: &Result{
+				URL:         svr.URL + "/redirect",
+				Method:      http.MethodHead,
+				StatusCode:  http.StatusOK,
+				ContentType: "text/html; charset=utf-8",
+				Redirects:   []string{svr.URL + "/moved", svr.URL + "/page"},
+			},
+		},
+		{
+			Name:    "not found",
+			Options: Options{AllowPrivateAddresses: true},
+			URL:     svr.URL + "/missing",
+			Expected: &Result{
+				URL:         svr.URL + "/missing",
+				Method:      http.MethodGet,
+				StatusCode:  http.StatusNotFound,
+				ContentType: "text/plain; charset=utf-8",
+				Error:       "the server responded with status 404 Not Found",
+			},
+		},
+		{
+			Name:    "private address",
+			Options: Options{},
+			URL:     svr.URL + "/page",
+			Expected: &Result{
+				URL:    svr.URL + "/page",
+				Method: http.MethodHead,
+				Error:  "the address is private, loopback or link-local",
+			},
+		},
+		{
+			Name:    "unsupported scheme",
+			Options: Options{AllowPrivateAddresses: true},
+			URL:     "file:///etc/passwd",
+			Expected: &Result{
+				URL:    "file:///etc/passwd",
+				Method: http.MethodHead,
+				Error:  "the scheme 'file' is not supported, it must be http or https",
+			},
+		},
+		{
+			Name:    "timeout",
+			Options: Options{AllowPrivateAddresses: true, Timeout: 50 * time.Millisecond},
+			URL:     svr.URL + "/slow",
+			Expected: &Result{
+				URL:    svr.URL + "/slow",
+				Method: http.MethodHead,
+				Error:  "the request timed out",
+			},
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			result := New(tc.Options).Resolve(context.Background(), tc.URL)
+			if !reflect.DeepEqual(result, tc.Expected) {
+				t.Fatalf("error: unexpected result;\nwant = %+v\ngot  = %+v", tc.Expected, result)
+			}
+		})
+	}
+}
+
+func TestResolveRedirectLoop(t *testing.T) {
+	svr := newTestServer()
+	defer svr.Close()
+
+	result := New(Options{AllowPrivateAddresses: true, MaxRedirects: 3}).Resolve(context.Background(), svr.URL+"/loop")
+	if result.Error != "stopped after 3 redirects" {
+		t.Fatalf("error: unexpected error; got = %v", result.Error)
+	}
+	if len(result.Redirects) != 4 {
+		t.Fatalf("error: expected the redirect chain to be reported; got = %v", result.Redirects)
+	}
+}
+
+func TestResolveTLS(t *testing.T) {
+	svr := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+	}))
+	defer svr.Close()
+
+	result := New(Options{AllowPrivateAddresses: true}).Resolve(context.Background(), svr.URL)
+	if result.Error != "" || result.ContentType != "text/html" {
+		t.Fatalf("error: expected the URL to resolve without verification; got = %+v", result)
+	}
+	if !strings.Contains(result.Insecure, "certificate signed by unknown authority") {
+		t.Fatalf("error: expected an insecure hint; got = %v", result.Insecure)
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(svr.Certificate())
+	result = New(Options{AllowPrivateAddresses: true, RootCAs: pool}).Resolve(context.Background(), svr.URL)
+	if result.Error != "" || result.Insecure != "" {
+		t.Fatalf("error: expected the URL to resolve with verification; got = %+v", result)
+	}
+}
+
+func TestFetch(t *testing.T) {
+	svr := newTestServer()
+	defer svr.Close()
+
+	r := New(Options{AllowPrivateAddresses: true})
+	result := r.Fetch(context.Background(), svr.URL+"/large", 11)
+	if result.Error != "" || string(result.Contents) != strings.Repeat("a", 11) {
+		t.Fatalf("error: unexpected result; got = %+v", result)
+	}
+	result = r.Fetch(context.Background(), svr.URL+"/large", 10)
+	if result.Error != "it is larger than the limit of 10 bytes" || result.Contents != nil {
+		t.Fatalf("error: unexpected result; got = %+v", result)
+	}
+}
+
+func TestResolveMaxConcurrentRequestsPerHost(t *testing.T) {
+	var current, highest int32
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&current, 1)
+		defer atomic.AddInt32(&current, -1)
+		for {
+			h := atomic.LoadInt32(&highest)
+			if n <= h || atomic.CompareAndSwapInt32(&highest, h, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		w.Header().Set("Content-Type", "text/html")
+	}))
+	defer svr.Close()
+
+	r := New(Options{AllowPrivateAddresses: true, MaxConcurrentRequestsPerHost: 2})
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.Resolve(context.Background(), svr.URL)
+		}()
+	}
+	wg.Wait()
+	if highest > 2 {
+		t.Fatalf("error: expected at most 2 concurrent requests; got = %v", highest)
+	}
+}
+
+func TestResolveForgetsHosts(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+	}))
+	defer svr.Close()
+
+	r := New(Options{AllowPrivateAddresses: true, MaxConcurrentRequestsPerHost: 1})
+	r.Resolve(context.Background(), svr.URL)
+	release, err := r.acquire(context.Background(), "example.com")
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	// a request which gives up waiting for the host is done too
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := r.acquire(ctx, "example.com"); err == nil {
+		t.Fatalf("error: expected to give up waiting for a slot")
+	}
+	release()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.hosts) != 0 {
+		t.Fatalf("error: expected the hosts to be forgotten once their requests are done; got = %v", r.hosts)
+	}
+}
+
+func TestIsBlockedIP(t *testing.T) {
+	for _, tc := range []struct {
+		IP       string
+		Expected bool
+	}{
+		{IP: "127.0.0.1", Expected: true},
+		{IP: "10.0.0.1", Expected: true},
+		{IP: "172.16.0.1", Expected: true},
+		{IP: "192.168.1.1", Expected: true},
+		{IP: "169.254.169.254", Expected: true},
+		{IP: "100.64.0.1", Expected: true},
+		{IP: "0.0.0.0", Expected: true},
+		{IP: "::1", Expected: true},
+		{IP: "fe80::1", Expected: true},
+		{IP: "fd00::1", Expected: true},
+		{IP: "151.101.1.140", Expected: false},
+		{IP: "2606:4700::1111", Expected: false},
+	} {
+		t.Run(tc.IP, func(t *testing.T) {
+			if got := IsBlockedIP(net.ParseIP(tc.IP)); got != tc.Expected {
+				t.Fatalf("error: unexpected result for %v; want = %v; got = %v", tc.IP, tc.Expected, got)
+			}
+		})
+	}
+}
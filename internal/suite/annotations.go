@@ -0,0 +1,104 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package suite
+
+import (
+	"html"
+	"path"
+	"regexp"
+	"strings"
+)
+
+var (
+	// annotationQuotedValue matches the values quoted in hints, such as a field name in 'product_logo_url'
+	annotationQuotedValue = regexp.MustCompile(`'([^']+)'`)
+	// annotationListItem matches the items listed in hints, such as the names of missing tests
+	annotationListItem = regexp.MustCompile(`(?m)^ +- (.+)$`)
+)
+
+// Annotation locates the hint of a failing step at a line of a submission file
+type Annotation struct {
+	Path      string `json:"path"`
+	StartLine int    `json:"startLine"`
+	EndLine   int    `json:"endLine"`
+	Title     string `json:"title"`
+	Message   string `json:"message"`
+}
+
+// getAnnotations returns an Annotation for each hint of the failed scenarios of r which mentions a submission file.
+// The line is the first one mentioning a value from the hint, such as a field or test name, otherwise the first line.
+func (s *PRSuite) getAnnotations(r *Report) (annotations []Annotation) {
+	for _, sc := range r.Scenarios {
+		if sc.Status != ScenarioStatusFailed {
+			continue
+		}
+		for _, hint := range sc.Hints {
+			name := r.fileMentionedIn(hint, sc.Name)
+			if name == "" {
+				continue
+			}
+			values := annotationValues(hint)
+			if path.Base(name) == "junit_01.xml" {
+				values = append(values, r.FailedTests...)
+			}
+			line := 1
+			if file := s.GetFileByFileName(path.Base(name)); file != nil {
+				line = lineMentioning(file.Contents, values)
+			}
+			annotations = append(annotations, Annotation{
+				Path:      name,
+				StartLine: line,
+				EndLine:   line,
+				Title:     sc.Name,
+				Message:   hint,
+			})
+		}
+	}
+	return annotations
+}
+
+// annotationValues returns the quoted values and list items of hint
+func annotationValues(hint string) (values []string) {
+	for _, m := range annotationQuotedValue.FindAllStringSubmatch(hint, -1) {
+		values = append(values, m[1])
+	}
+	for _, m := range annotationListItem.FindAllStringSubmatch(hint, -1) {
+		values = append(values, strings.TrimSpace(m[1]))
+	}
+	return values
+}
+
+// lineMentioning returns the number of the first line of contents with a YAML key of one of values,
+// or else the first line containing one of them, as is or HTML escaped as in XML, otherwise 1
+func lineMentioning(contents string, values []string) int {
+	lines := strings.Split(contents, "\n")
+	for _, v := range values {
+		for i, l := range lines {
+			if strings.HasPrefix(strings.TrimSpace(l), v+":") {
+				return i + 1
+			}
+		}
+	}
+	for _, v := range values {
+		for i, l := range lines {
+			if strings.Contains(l, v) || strings.Contains(l, html.EscapeString(v)) {
+				return i + 1
+			}
+		}
+	}
+	return 1
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package suite
+
+import (
+	"reflect"
+	"testing"
+)
+
+const (
+	testAnnotationsProductYAML = `vendor: Cool Kube
+name: coolkube
+version: v1.35.0
+website_url: https://example.com/coolkube
+`
+	testAnnotationsJunitXML = `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="Kubernetes e2e suite">
+    <testcase name="[It] [sig-node] Pods should be submitted and removed [Conformance]"></testcase>
+    <testcase name="[It] [sig-apps] Deployment should &#39;rollover&#39; [Conformance]">
+      <failure>timed out</failure>
+    </testcase>
+  </testsuite>
+</testsuites>
+`
+)
+
+func TestGetAnnotations(t *testing.T) {
+	type testCase struct {
+		Name                string
+		Scenarios           []ScenarioResult
+		FailedTests         []string
+		ExpectedAnnotations []Annotation
+	}
+
+	for _, tc := range []testCase{
+		{
+			Name: "passing scenarios have no annotations",
+			Scenarios: []ScenarioResult{
+				{Name: "the PRODUCT.yaml metadata is valid", Status: ScenarioStatusPassed},
+			},
+		},
+		{
+			Name: "field in PRODUCT.yaml",
+			Scenarios: []ScenarioResult{
+				{
+					Name:   "the URL fields in the PRODUCT.yaml resolve to their specified data types",
+					Status: ScenarioStatusFailed,
+					Hints:  []string{"URL field 'website_url' in PRODUCT.yaml resolving content type 'text/plain' must be (text/html)"},
+				},
+			},
+			ExpectedAnnotations: []Annotation{
+				{
+					Path:      "v1.35/coolkube/PRODUCT.yaml",
+					StartLine: 4,
+					EndLine:   4,
+					Title:     "the URL fields in the PRODUCT.yaml resolve to their specified data types",
+					Message:   "URL field 'website_url' in PRODUCT.yaml resolving content type 'text/plain' must be (text/html)",
+				},
+			},
+		},
+		{
+			Name: "missing field in PRODUCT.yaml is at the first line",
+			Scenarios: []ScenarioResult{
+				{
+					Name:   "the PRODUCT.yaml metadata is valid",
+					Status: ScenarioStatusFailed,
+					Hints:  []string{"missing required field 'contact_email_address' in 'PRODUCT.yaml'"},
+				},
+			},
+			ExpectedAnnotations: []Annotation{
+				{
+					Path:      "v1.35/coolkube/PRODUCT.yaml",
+					StartLine: 1,
+					EndLine:   1,
+					Title:     "the PRODUCT.yaml metadata is valid",
+					Message:   "missing required field 'contact_email_address' in 'PRODUCT.yaml'",
+				},
+			},
+		},
+		{
+			Name: "failed testcase in junit_01.xml",
+			Scenarios: []ScenarioResult{
+				{
+					Name:   "all tests pass",
+					Status: ScenarioStatusFailed,
+					Hints:  []string{"it appears that there are failures in some tests in the junit_01.xml"},
+				},
+			},
+			FailedTests: []string{"[sig-apps] Deployment should 'rollover' [Conformance]"},
+			ExpectedAnnotations: []Annotation{
+				{
+					Path:      "v1.35/coolkube/junit_01.xml",
+					StartLine: 5,
+					EndLine:   5,
+					Title:     "all tests pass",
+					Message:   "it appears that there are failures in some tests in the junit_01.xml",
+				},
+			},
+		},
+		{
+			Name: "hint without a file",
+			Scenarios: []ScenarioResult{
+				{
+					Name:   "there is only one commit",
+					Status: ScenarioStatusFailed,
+					Hints:  []string{"more than one commit was found; only one commit is allowed"},
+				},
+			},
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			prSuite := NewPRSuite(&PullRequest{
+				SupportingFiles: []*PullRequestFile{
+					{Name: "v1.35/coolkube/PRODUCT.yaml", BaseName: "PRODUCT.yaml", Contents: testAnnotationsProductYAML},
+					{Name: "v1.35/coolkube/junit_01.xml", BaseName: "junit_01.xml", Contents: testAnnotationsJunitXML},
+				},
+			})
+			r := prSuite.NewReport("", []string{}, "failure")
+			r.Scenarios = tc.Scenarios
+			r.FailedTests = tc.FailedTests
+			if annotations := prSuite.getAnnotations(r); !reflect.DeepEqual(annotations, tc.ExpectedAnnotations) {
+				t.Fatalf("error: unexpected annotations;\nwant = %+v\ngot  = %+v", tc.ExpectedAnnotations, annotations)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package suite
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"reflect"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+const (
+	// TemplatesFolder is the folder in the data with the comment templates, each a *.md.tmpl file
+	TemplatesFolder = "templates"
+
+	CommentTemplateResults            = "results.md.tmpl"
+	CommentTemplateUnableToProcess    = "unable-to-process.md.tmpl"
+	CommentTemplateUnsupportedRelease = "unsupported-release.md.tmpl"
+	CommentTemplateNotConformance     = "not-conformance.md.tmpl"
+	CommentTemplateDraft              = "draft.md.tmpl"
+
+	// commentTemplatesCacheSize is the number of data FS of which the parsed comment templates are kept
+	commentTemplatesCacheSize = 8
+)
+
+// commentTemplateNames are the templates a comment is rendered from, which must all be present
+var commentTemplateNames = []string{
+	CommentTemplateResults,
+	CommentTemplateUnableToProcess,
+	CommentTemplateUnsupportedRelease,
+	CommentTemplateNotConformance,
+	CommentTemplateDraft,
+}
+
+var (
+	commentTemplatesMu sync.Mutex
+	// commentTemplates are the parsed comment templates by the data FS they are from,
+	// which doesn't change once in use
+	commentTemplates = map[fs.FS]*template.Template{}
+)
+
+var commentTemplateFuncs = template.FuncMap{
+	"join": strings.Join,
+	// capitalize upper cases the first letter, such as to start a comment with an error message
+	"capitalize": func(s string) string {
+		if s == "" {
+			return s
+		}
+		return strings.ToUpper(s[:1]) + s[1:]
+	},
+}
+
+// CommentData is the input of the comment templates
+type CommentData struct {
+	// Title is the title of the PR
+	Title string
+	// ReleaseVersion is the release version of the submission, like v1.35
+	ReleaseVersion string
+	// PatchRelease is the patch release in the title, like v1.35.2, when the submission is for one
+	PatchRelease string
+	// ReleaseSupportEnd describes when the release stops being accepted, when it is known
+	ReleaseSupportEnd string
+	ProductName       string
+
+	// Requirements is the number of requirements checked, of which Passed have passed
+	Requirements int
+	Passed       int
+	// Failures are the failed requirements by description, with the hints of their failing steps escaped for markdown
+	Failures []ResultPrepare
+	// Scenarios are the results of each scenario, with hints which aren't escaped
+	Scenarios    []ScenarioResult
+	MissingTests []string
+	FailedTests  []string
+
+	// Error is the reason the submission isn't checked, for unsupported-release.md.tmpl
+	Error string
+}
+
+// NewCommentData returns the CommentData for the submission, before the requirements are checked
+func (s *PRSuite) NewCommentData() *CommentData {
+	d := &CommentData{
+		Title:          string(s.PR.Title),
+		ReleaseVersion: s.KubernetesReleaseVersion,
+		ProductName:    s.ProductName,
+	}
+	if _, titleReleaseVersion, _ := s.titleParts(); titleReleaseVersion != nil && titleReleaseVersion.HasPatch && titleReleaseVersion.MinorVersion() == s.KubernetesReleaseVersion {
+		d.PatchRelease = titleReleaseVersion.String()
+	}
+	if support, err := s.releaseSupport(); err == nil && support.Supported {
+		d.ReleaseSupportEnd = s.describeReleaseSupportEnd(support)
+	}
+	return d
+}
+
+// ParseCommentTemplates parses the comment templates in the templates folder of fsys
+func ParseCommentTemplates(fsys fs.FS) (*template.Template, error) {
+	t, err := template.New("").Funcs(commentTemplateFuncs).ParseFS(fsys, path.Join(TemplatesFolder, "*.md.tmpl"))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the comment templates, %v", err)
+	}
+	return t, nil
+}
+
+// getCommentTemplates returns the comment templates of fsys, parsing them once for each data FS
+func getCommentTemplates(fsys fs.FS) (*template.Template, error) {
+	// data like fstest.MapFS can't be a map key, so it is parsed each time
+	if !reflect.TypeOf(fsys).Comparable() {
+		return ParseCommentTemplates(fsys)
+	}
+	commentTemplatesMu.Lock()
+	defer commentTemplatesMu.Unlock()
+	if t, found := commentTemplates[fsys]; found {
+		return t, nil
+	}
+	t, err := ParseCommentTemplates(fsys)
+	if err != nil {
+		return nil, err
+	}
+	if len(commentTemplates) >= commentTemplatesCacheSize {
+		clear(commentTemplates)
+	}
+	commentTemplates[fsys] = t
+	return t, nil
+}
+
+// ValidateCommentTemplates checks that the comment templates in fsys parse and render an example submission
+func ValidateCommentTemplates(fsys fs.FS) error {
+	t, err := ParseCommentTemplates(fsys)
+	if err != nil {
+		return err
+	}
+	example := &CommentData{
+		Title:          "Conformance results for v1.35/coolkube",
+		ReleaseVersion: "v1.35",
+		ProductName:    "coolkube",
+		Requirements:   2,
+		Passed:         1,
+		Failures:       []ResultPrepare{{Name: "it appears that some tests failed in the product submission", Hints: []string{"it appears that there are failures in some tests"}}},
+		Scenarios:      []ScenarioResult{{Name: "all tests pass", Status: ScenarioStatusFailed}},
+		Error:          "unable to use version v1.30",
+	}
+	for _, name := range commentTemplateNames {
+		if t.Lookup(name) == nil {
+			return fmt.Errorf("missing the comment template '%v'", name)
+		}
+		if err := t.ExecuteTemplate(io.Discard, name, example); err != nil {
+			return fmt.Errorf("unable to render the comment template '%v', %v", name, err)
+		}
+	}
+	return nil
+}
+
+// RenderComment renders the comment template name from the data of the suite
+func (s *PRSuite) RenderComment(name string, data *CommentData) (string, error) {
+	t, err := getCommentTemplates(s.DataFS)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := t.ExecuteTemplate(&buf, name, data); err != nil {
+		return "", fmt.Errorf("unable to render the comment template '%v', %v", name, err)
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,231 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package suite
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"sigs.k8s.io/verify-conformance/internal/common"
+)
+
+func TestRenderComment(t *testing.T) {
+	type testCase struct {
+		Name                string
+		Template            string
+		Data                *CommentData
+		ExpectedComment     string
+		ExpectedErrorString string
+	}
+
+	for _, tc := range []testCase{
+		{
+			Name:            "all requirements passed",
+			Template:        CommentTemplateResults,
+			Data:            &CommentData{ReleaseVersion: "v1.35", Requirements: 20, Passed: 20},
+			ExpectedComment: "All requirements (20) have passed for the submission!\n",
+		},
+		{
+			Name:     "failed requirements with a patch release and the end of support",
+			Template: CommentTemplateResults,
+			Data: &CommentData{
+				ReleaseVersion:    "v1.35",
+				PatchRelease:      "v1.35.2",
+				ReleaseSupportEnd: "v1.35 is accepted until 2027-02-20, 60 days after the release of v1.39",
+				Requirements:      20,
+				Passed:            19,
+				Failures:          []ResultPrepare{{Name: "it appears that some tests failed in the product submission", Hints: []string{"it appears that there are failures in some tests in the junit_01.xml"}}},
+			},
+			ExpectedComment: "19 of 20 requirements have passed. Please review the following:\n" +
+				"- [FAIL] it appears that some tests failed in the product submission\n" +
+				"  - it appears that there are failures in some tests in the junit_01.xml\n" +
+				"\n for a full list of requirements, please refer to these sections of the docs: [_content of the PR_](https://github.com/cncf/k8s-conformance/blob/master/instructions.md#contents-of-the-pr), and [_requirements_](https://github.com/cncf/k8s-conformance/blob/master/instructions.md#requirements)." +
+				"\n\nThe submission is for the patch release v1.35.2 of v1.35." +
+				"\n\nThe release v1.35 is accepted until 2027-02-20, 60 days after the release of v1.39.\n",
+		},
+		{
+			Name:     "missing tests listed in the hint",
+			Template: CommentTemplateResults,
+			Data: &CommentData{
+				ReleaseVersion: "v1.35",
+				Requirements:   17,
+				Passed:         16,
+				Failures: []ResultPrepare{{
+					Name:  "it appears that some tests are missing from the product submission",
+					Hints: []string{"the following test(s) are missing or failed: \n    - [sig-apps] Deployment should run the lifecycle of a Deployment [Conformance]"},
+				}},
+				MissingTests: []string{"[sig-apps] Deployment should run the lifecycle of a Deployment [Conformance]"},
+			},
+			ExpectedComment: "16 of 17 requirements have passed. Please review the following:\n" +
+				"- [FAIL] it appears that some tests are missing from the product submission\n" +
+				"  - the following test(s) are missing or failed: \n" +
+				"    - [sig-apps] Deployment should run the lifecycle of a Deployment [Conformance]\n" +
+				"\n for a full list of requirements, please refer to these sections of the docs: [_content of the PR_](https://github.com/cncf/k8s-conformance/blob/master/instructions.md#contents-of-the-pr), and [_requirements_](https://github.com/cncf/k8s-conformance/blob/master/instructions.md#requirements).\n",
+		},
+		{
+			Name:            "unable to process",
+			Template:        CommentTemplateUnableToProcess,
+			Data:            &CommentData{ReleaseVersion: "v1.37"},
+			ExpectedComment: "The release version v1.37 is unable to be processed at this time; Please wait as this version may become available soon.",
+		},
+		{
+			Name:            "unsupported release",
+			Template:        CommentTemplateUnsupportedRelease,
+			Data:            &CommentData{Error: "unable to use version v1.20 because it is older than the last currently supported release v1.33"},
+			ExpectedComment: "Unable to use version v1.20 because it is older than the last currently supported release v1.33.",
+		},
+		{
+			Name:            "not a conformance submission",
+			Template:        CommentTemplateNotConformance,
+			Data:            &CommentData{},
+			ExpectedComment: "This pull request appears to not be a conformance results submission, because its title doesn't include \"conformance results for\"; Checks will not run.\n\nIf this change is intended to be verified as a conformance results submission see: [_content of the PR_](https://github.com/cncf/k8s-conformance/blob/master/instructions.md#contents-of-the-pr), and [_requirements_](https://github.com/cncf/k8s-conformance/blob/master/instructions.md#requirements)",
+		},
+		{
+			Name:            "draft",
+			Template:        CommentTemplateDraft,
+			Data:            &CommentData{},
+			ExpectedComment: "This conformance results submission is a draft; Checks will run once it is marked as ready for review.",
+		},
+		{
+			Name:                "unknown template",
+			Template:            "soup.md.tmpl",
+			Data:                &CommentData{},
+			ExpectedErrorString: "unable to render the comment template 'soup.md.tmpl'",
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			prSuite := NewPRSuite(&PullRequest{})
+			comment, err := prSuite.RenderComment(tc.Template, tc.Data)
+			if tc.ExpectedErrorString == "" && err != nil {
+				t.Fatalf("error: unexpected error: %v", err)
+			}
+			if tc.ExpectedErrorString != "" && (err == nil || !strings.Contains(err.Error(), tc.ExpectedErrorString)) {
+				t.Fatalf("error: expected error containing '%v'; got = %v", tc.ExpectedErrorString, err)
+			}
+			if comment != tc.ExpectedComment {
+				t.Fatalf("error: unexpected comment;\nwant = %q\ngot  = %q", tc.ExpectedComment, comment)
+			}
+		})
+	}
+}
+
+func TestRenderCommentCustomTemplate(t *testing.T) {
+	prSuite := NewPRSuite(&PullRequest{})
+	prSuite.DataFS = fstest.MapFS{
+		"templates/results.md.tmpl": {Data: []byte(`{{ .Passed }}/{{ .Requirements }} for {{ .ProductName }}
+{{- if .MissingTests }}
+<details>
+<summary>{{ len .MissingTests }} missing tests</summary>
+
+- {{ join .MissingTests "\n- " }}
+</details>
+{{- end }}
+`)},
+	}
+	comment, err := prSuite.RenderComment(CommentTemplateResults, &CommentData{
+		ProductName:  "coolkube",
+		Requirements: 20,
+		Passed:       19,
+		MissingTests: []string{"[sig-node] Pods should be submitted and removed [Conformance]", "[sig-apps] Deployment should rollover [Conformance]"},
+	})
+	if err != nil {
+		t.Fatalf("error: unexpected error: %v", err)
+	}
+	expected := "19/20 for coolkube\n<details>\n<summary>2 missing tests</summary>\n\n- [sig-node] Pods should be submitted and removed [Conformance]\n- [sig-apps] Deployment should rollover [Conformance]\n</details>\n"
+	if comment != expected {
+		t.Fatalf("error: unexpected comment;\nwant = %q\ngot  = %q", expected, comment)
+	}
+}
+
+func TestRenderCommentParsesTemplatesOnce(t *testing.T) {
+	dir := t.TempDir()
+	templates := filepath.Join(dir, TemplatesFolder)
+	if err := os.MkdirAll(templates, 0755); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	write := func(contents string) {
+		if err := os.WriteFile(filepath.Join(templates, CommentTemplateDraft), []byte(contents), 0644); err != nil {
+			t.Fatalf("error: %v", err)
+		}
+	}
+	prSuite := NewPRSuite(&PullRequest{})
+	prSuite.DataFS = os.DirFS(dir)
+
+	write("first")
+	if comment, err := prSuite.RenderComment(CommentTemplateDraft, &CommentData{}); err != nil || comment != "first" {
+		t.Fatalf("error: unexpected comment; got = %q, %v", comment, err)
+	}
+	// the data of a suite doesn't change, so the templates aren't read again
+	write("second")
+	if comment, err := prSuite.RenderComment(CommentTemplateDraft, &CommentData{}); err != nil || comment != "first" {
+		t.Fatalf("error: expected the parsed templates to be reused; got = %q, %v", comment, err)
+	}
+}
+
+func TestValidateCommentTemplates(t *testing.T) {
+	templates := map[string]string{}
+	for _, name := range commentTemplateNames {
+		templates[name] = "{{ .ReleaseVersion }}"
+	}
+
+	for _, tc := range []struct {
+		Name                string
+		Templates           map[string]string
+		ExpectedErrorString string
+	}{
+		{
+			Name:      "valid",
+			Templates: templates,
+		},
+		{
+			Name:                "no templates",
+			Templates:           map[string]string{},
+			ExpectedErrorString: "unable to parse the comment templates",
+		},
+		{
+			Name:                "missing template",
+			Templates:           map[string]string{CommentTemplateResults: "{{ .Passed }}"},
+			ExpectedErrorString: "missing the comment template 'unable-to-process.md.tmpl'",
+		},
+		{
+			Name:                "syntax error",
+			Templates:           map[string]string{CommentTemplateResults: "{{ if .Passed }}"},
+			ExpectedErrorString: "unable to parse the comment templates",
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			fsys := fstest.MapFS{}
+			for name, contents := range tc.Templates {
+				fsys["templates/"+name] = &fstest.MapFile{Data: []byte(contents)}
+			}
+			err := ValidateCommentTemplates(fsys)
+			if tc.ExpectedErrorString == "" && err != nil {
+				t.Fatalf("error: unexpected error: %v", err)
+			}
+			if tc.ExpectedErrorString != "" && (err == nil || !strings.Contains(err.Error(), tc.ExpectedErrorString)) {
+				t.Fatalf("error: expected error containing '%v'; got = %v", tc.ExpectedErrorString, err)
+			}
+		})
+	}
+
+	if err := ValidateCommentTemplates(common.GetDataFS()); err != nil {
+		t.Fatalf("error: expected the templates of kodata to be valid: %v", err)
+	}
+}
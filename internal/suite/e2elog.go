@@ -0,0 +1,203 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package suite
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	sonobuoyresults "github.com/vmware-tanzu/sonobuoy/pkg/client/results"
+
+	"sigs.k8s.io/verify-conformance/internal/common"
+)
+
+var (
+	// e2eLogServerVersionRegexp matches the version of the cluster tested, e.g. "kube-apiserver version: v1.35.0"
+	e2eLogServerVersionRegexp = regexp.MustCompile(`kube-apiserver version: ((` + ReleaseVersionPattern + `)\S*)`)
+	// e2eLogClientVersionRegexp matches the version of the e2e tests run, e.g. "e2e test version: v1.35.0"
+	e2eLogClientVersionRegexp = regexp.MustCompile(`e2e test version: ((` + ReleaseVersionPattern + `)\S*)`)
+	// e2eLogRanSpecsRegexp matches the Ginkgo summary, e.g. "Ran 441 of 7348 Specs in 11492.672 seconds"
+	e2eLogRanSpecsRegexp = regexp.MustCompile(`Ran ([0-9]+) of ([0-9]+) Specs`)
+	// e2eLogTotalsRegexp matches the Ginkgo totals, e.g. "SUCCESS! -- 441 Passed | 0 Failed | 0 Pending | 6907 Skipped"
+	e2eLogTotalsRegexp = regexp.MustCompile(`([0-9]+) Passed \| ([0-9]+) Failed \| ([0-9]+) Pending \| ([0-9]+) Skipped`)
+
+	// ginkgoSuiteNodePrefixes name the setup and teardown nodes in a junit_01.xml, which aren't specs
+	ginkgoSuiteNodePrefixes = []string{
+		"[BeforeSuite]",
+		"[SynchronizedBeforeSuite]",
+		"[AfterSuite]",
+		"[SynchronizedAfterSuite]",
+		"[ReportBeforeSuite]",
+		"[ReportAfterSuite]",
+		"[DeferCleanup (Suite)]",
+	}
+)
+
+// E2eLog is the summary of a conformance test run found in an e2e.log
+type E2eLog struct {
+	// ServerVersion is the version of the kube-apiserver tested, e.g. v1.35.0
+	ServerVersion string
+	// ServerReleaseVersion is the release of ServerVersion, e.g. v1.35
+	ServerReleaseVersion string
+	// ClientVersion is the version of the e2e tests run, e.g. v1.35.0
+	ClientVersion string
+	// ClientReleaseVersion is the release of ClientVersion, e.g. v1.35
+	ClientReleaseVersion string
+
+	// HasSummary is whether the run finished with a summary of its results,
+	// which the counts below are from
+	HasSummary bool
+	SpecsRan   int
+	SpecsTotal int
+	Passed     int
+	Failed     int
+	Pending    int
+	Skipped    int
+}
+
+// ParseE2eLog returns the summary of the test run in the contents of an e2e.log,
+// leaving anything which is unable to be found empty
+func ParseE2eLog(contents string) *E2eLog {
+	e2eLog := &E2eLog{}
+	if m := e2eLogServerVersionRegexp.FindStringSubmatch(contents); m != nil {
+		if v, err := ParseReleaseVersion(m[2]); err == nil {
+			e2eLog.ServerVersion, e2eLog.ServerReleaseVersion = m[1], v.MinorVersion()
+		}
+	}
+	if m := e2eLogClientVersionRegexp.FindStringSubmatch(contents); m != nil {
+		if v, err := ParseReleaseVersion(m[2]); err == nil {
+			e2eLog.ClientVersion, e2eLog.ClientReleaseVersion = m[1], v.MinorVersion()
+		}
+	}
+
+	// the summary is at the end of the log, after the output of every spec
+	ran := e2eLogRanSpecsRegexp.FindAllStringSubmatch(contents, -1)
+	totals := e2eLogTotalsRegexp.FindAllStringSubmatch(contents, -1)
+	if len(ran) > 0 && len(totals) > 0 {
+		e2eLog.HasSummary = true
+		e2eLog.SpecsRan, _ = strconv.Atoi(ran[len(ran)-1][1])
+		e2eLog.SpecsTotal, _ = strconv.Atoi(ran[len(ran)-1][2])
+		t := totals[len(totals)-1]
+		e2eLog.Passed, _ = strconv.Atoi(t[1])
+		e2eLog.Failed, _ = strconv.Atoi(t[2])
+		e2eLog.Pending, _ = strconv.Atoi(t[3])
+		e2eLog.Skipped, _ = strconv.Atoi(t[4])
+		return e2eLog
+	}
+
+	// older versions of the e2e tests also report progress as JSON, i.e:
+	// {"msg":"Test Suite completed","total":346,"completed":346,"skipped":5387,"failed":0}
+	for _, line := range strings.Split(contents, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, `{"msg":`) {
+			continue
+		}
+		testPass := E2eLogTestPass{}
+		if err := json.Unmarshal([]byte(line), &testPass); err != nil || testPass.Message != "Test Suite completed" {
+			continue
+		}
+		e2eLog.HasSummary = true
+		e2eLog.SpecsRan = testPass.Completed + testPass.Failed
+		e2eLog.SpecsTotal = testPass.Total + testPass.Skipped
+		e2eLog.Passed = testPass.Completed
+		e2eLog.Failed = testPass.Failed
+		e2eLog.Skipped = testPass.Skipped
+	}
+	return e2eLog
+}
+
+// GetE2eLog parses the e2e.log in the PR, setting E2eLogKubernetesReleaseVersion
+func (s *PRSuite) GetE2eLog() (*E2eLog, error) {
+	file := s.GetFileByFileName("e2e.log")
+	if file == nil {
+		return nil, fmt.Errorf("unable to find file e2e.log")
+	}
+	e2eLog := ParseE2eLog(file.Contents)
+	s.E2eLogKubernetesReleaseVersion = e2eLog.ServerReleaseVersion
+	return e2eLog, nil
+}
+
+// getJunitSpecCounts returns the number of specs which ran and failed in the junit_01.xml,
+// leaving out the setup and teardown of the suite as the e2e.log summary does
+func (s *PRSuite) getJunitSpecCounts() (ran int, failed int, err error) {
+	file := s.GetFileByFileName("junit_01.xml")
+	if file == nil {
+		return 0, 0, fmt.Errorf("unable to find file junit_01.xml")
+	}
+	junit := sonobuoyresults.JUnitTestSuites{}
+	if err := xml.Unmarshal([]byte(file.Contents), &junit); err != nil {
+		return 0, 0, common.SafeError(fmt.Errorf("unable to parse junit_01.xml file, %v", err))
+	}
+	for _, suite := range junit.Suites {
+	testcases:
+		for _, testcase := range suite.TestCases {
+			if testcase.SkipMessage != nil {
+				continue
+			}
+			for _, prefix := range ginkgoSuiteNodePrefixes {
+				if strings.HasPrefix(testcase.Name, prefix) {
+					continue testcases
+				}
+			}
+			ran++
+			if testcase.Failure != nil || testcase.ErrorMessage != nil {
+				failed++
+			}
+		}
+	}
+	return ran, failed, nil
+}
+
+func (s *PRSuite) theKubernetesReleaseVersionInTheE2eLogMatchesTheReleaseVersion() error {
+	e2eLog, err := s.GetE2eLog()
+	if err != nil {
+		return err
+	}
+	if e2eLog.ServerVersion == "" {
+		return common.SafeError(fmt.Errorf("unable to find the Kubernetes version tested (kube-apiserver version) in the e2e.log"))
+	}
+	if e2eLog.ServerReleaseVersion != s.KubernetesReleaseVersion {
+		return common.SafeError(fmt.Errorf("the e2e.log is from a run against Kubernetes %v, which does not match the release version of the submission (%v)", e2eLog.ServerVersion, s.KubernetesReleaseVersion))
+	}
+	if e2eLog.ClientVersion != "" && e2eLog.ClientReleaseVersion != s.KubernetesReleaseVersion {
+		return common.SafeError(fmt.Errorf("the e2e.log is from a run of the e2e tests %v, which does not match the release version of the submission (%v)", e2eLog.ClientVersion, s.KubernetesReleaseVersion))
+	}
+	return nil
+}
+
+func (s *PRSuite) theResultsInTheE2eLogMatchTheJunitXml() error {
+	e2eLog, err := s.GetE2eLog()
+	if err != nil {
+		return err
+	}
+	if !e2eLog.HasSummary {
+		return common.SafeError(fmt.Errorf("unable to find the summary of the results (Ran X of Y Specs) in the e2e.log; the test run may not have finished"))
+	}
+	ran, failed, err := s.getJunitSpecCounts()
+	if err != nil {
+		return err
+	}
+	if e2eLog.SpecsRan != ran || e2eLog.Failed != failed || e2eLog.Passed != ran-failed {
+		return common.SafeError(fmt.Errorf("the e2e.log reports %v specs ran (%v passed, %v failed), but the junit_01.xml has %v specs ran (%v passed, %v failed)",
+			e2eLog.SpecsRan, e2eLog.Passed, e2eLog.Failed, ran, ran-failed, failed))
+	}
+	return nil
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package suite
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseE2eLog(t *testing.T) {
+	type testCase struct {
+		Name     string
+		Contents string
+		Expected *E2eLog
+	}
+
+	for _, tc := range []testCase{
+		{
+			Name:     "ginkgo v2 log",
+			Contents: testParseE2eLogCoolkubeV135E2eLog,
+			Expected: &E2eLog{
+				ServerVersion:        "v1.35.0",
+				ServerReleaseVersion: "v1.35",
+				ClientVersion:        "v1.35.0",
+				ClientReleaseVersion: "v1.35",
+				HasSummary:           true,
+				SpecsRan:             441,
+				SpecsTotal:           7348,
+				Passed:               441,
+				Skipped:              6907,
+			},
+		},
+		{
+			Name: "ginkgo v2 log with a failure",
+			Contents: `  I0106 13:08:18.043385 22 e2e.go:170] kube-apiserver version: v1.34.2+k3s1
+Ran 441 of 7348 Specs in 11492.672 seconds
+FAIL! -- 440 Passed | 1 Failed | 0 Pending | 6907 Skipped`,
+			Expected: &E2eLog{
+				ServerVersion:        "v1.34.2+k3s1",
+				ServerReleaseVersion: "v1.34",
+				HasSummary:           true,
+				SpecsRan:             441,
+				SpecsTotal:           7348,
+				Passed:               440,
+				Failed:               1,
+				Skipped:              6907,
+			},
+		},
+		{
+			Name: "log with progress as json",
+			Contents: `Jun 17 09:27:15.123: INFO: kube-apiserver version: v1.21.1
+{"msg":"Test Suite starting","total":346,"completed":0,"skipped":0,"failed":0}
+{"msg":"PASSED [sig-node] Pods should be updated [NodeConformance] [Conformance]","total":346,"completed":1,"skipped":10,"failed":0}
+{"msg":"Test Suite completed","total":346,"completed":346,"skipped":5387,"failed":0}`,
+			Expected: &E2eLog{
+				ServerVersion:        "v1.21.1",
+				ServerReleaseVersion: "v1.21",
+				HasSummary:           true,
+				SpecsRan:             346,
+				SpecsTotal:           5733,
+				Passed:               346,
+				Skipped:              5387,
+			},
+		},
+		{
+			Name:     "unfinished log",
+			Contents: `Will run 441 of 7348 specs`,
+			Expected: &E2eLog{},
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			if got := ParseE2eLog(tc.Contents); !reflect.DeepEqual(got, tc.Expected) {
+				t.Fatalf("error: unexpected e2e.log summary; want = %+v; got = %+v", tc.Expected, got)
+			}
+		})
+	}
+}
+
+func TestTheKubernetesReleaseVersionInTheE2eLogMatchesTheReleaseVersion(t *testing.T) {
+	type testCase struct {
+		Name                string
+		ReleaseVersion      string
+		E2eLog              string
+		ExpectedErrorString string
+	}
+
+	for _, tc := range []testCase{
+		{
+			Name:           "matching release version",
+			ReleaseVersion: "v1.35",
+			E2eLog:         testParseE2eLogCoolkubeV135E2eLog,
+		},
+		{
+			Name:                "different server release version",
+			ReleaseVersion:      "v1.34",
+			E2eLog:              testParseE2eLogCoolkubeV135E2eLog,
+			ExpectedErrorString: "the e2e.log is from a run against Kubernetes v1.35.0, which does not match the release version of the submission (v1.34)",
+		},
+		{
+			Name:                "different client release version",
+			ReleaseVersion:      "v1.35",
+			E2eLog:              "e2e test version: v1.34.1\nkube-apiserver version: v1.35.0",
+			ExpectedErrorString: "the e2e.log is from a run of the e2e tests v1.34.1",
+		},
+		{
+			Name:                "missing version",
+			ReleaseVersion:      "v1.35",
+			E2eLog:              "cool!",
+			ExpectedErrorString: "unable to find the Kubernetes version tested",
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			prSuite := NewPRSuite(&PullRequest{
+				SupportingFiles: []*PullRequestFile{
+					{
+						Name:     tc.ReleaseVersion + "/coolkube/e2e.log",
+						BaseName: "e2e.log",
+						Contents: tc.E2eLog,
+					},
+				},
+			})
+			prSuite.KubernetesReleaseVersion = tc.ReleaseVersion
+			err := prSuite.theKubernetesReleaseVersionInTheE2eLogMatchesTheReleaseVersion()
+			if tc.ExpectedErrorString == "" && err != nil {
+				t.Fatalf("error: unexpected error: %v", err)
+			}
+			if tc.ExpectedErrorString != "" && (err == nil || !strings.Contains(err.Error(), tc.ExpectedErrorString)) {
+				t.Fatalf("error: expected error containing '%v'; got = %v", tc.ExpectedErrorString, err)
+			}
+		})
+	}
+}
+
+func TestTheResultsInTheE2eLogMatchTheJunitXml(t *testing.T) {
+	type testCase struct {
+		Name                string
+		E2eLog              string
+		JunitXML            string
+		ExpectedErrorString string
+	}
+
+	for _, tc := range []testCase{
+		{
+			Name:     "matching results",
+			E2eLog:   testParseE2eLogCoolkubeV135E2eLog,
+			JunitXML: testGetJunitSubmittedConformanceTestsCoolkubeV133Junit_01xml,
+		},
+		{
+			Name:     "matching results with a failure",
+			E2eLog:   "Ran 441 of 7348 Specs in 11492.672 seconds\nFAIL! -- 440 Passed | 1 Failed | 0 Pending | 6907 Skipped",
+			JunitXML: testGetJunitSubmittedConformanceTestsCoolkubeV133Junit_01WithOneTestFailedxml,
+		},
+		{
+			Name:                "junit from a run with a failure",
+			E2eLog:              testParseE2eLogCoolkubeV135E2eLog,
+			JunitXML:            testGetJunitSubmittedConformanceTestsCoolkubeV133Junit_01WithOneTestFailedxml,
+			ExpectedErrorString: "the e2e.log reports 441 specs ran (441 passed, 0 failed), but the junit_01.xml has 441 specs ran (440 passed, 1 failed)",
+		},
+		{
+			Name:                "junit from a run with a missing test",
+			E2eLog:              testParseE2eLogCoolkubeV135E2eLog,
+			JunitXML:            testGetJunitSubmittedConformanceTestsCoolkubeV133Junit_01WithOneTestMissingxml,
+			ExpectedErrorString: "but the junit_01.xml has 440 specs ran (440 passed, 0 failed)",
+		},
+		{
+			Name:                "unfinished run",
+			E2eLog:              "Will run 441 of 7348 specs",
+			JunitXML:            testGetJunitSubmittedConformanceTestsCoolkubeV133Junit_01xml,
+			ExpectedErrorString: "unable to find the summary of the results",
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			prSuite := NewPRSuite(&PullRequest{
+				SupportingFiles: []*PullRequestFile{
+					{
+						Name:     "v1.35/coolkube/e2e.log",
+						BaseName: "e2e.log",
+						Contents: tc.E2eLog,
+					},
+					{
+						Name:     "v1.35/coolkube/junit_01.xml",
+						BaseName: "junit_01.xml",
+						Contents: tc.JunitXML,
+					},
+				},
+			})
+			err := prSuite.theResultsInTheE2eLogMatchTheJunitXml()
+			if tc.ExpectedErrorString == "" && err != nil {
+				t.Fatalf("error: unexpected error: %v", err)
+			}
+			if tc.ExpectedErrorString != "" && (err == nil || !strings.Contains(err.Error(), tc.ExpectedErrorString)) {
+				t.Fatalf("error: expected error containing '%v'; got = %v", tc.ExpectedErrorString, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package suite
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"sigs.k8s.io/verify-conformance/internal/common"
+)
+
+// ExistingSubmission is a submission folder already on the base branch of the conformance repo
+type ExistingSubmission struct {
+	// Folder is the path of the submission, like v1.35/coolkube
+	Folder string
+	// ProductYAML is the metadata of the submission, nil when it is missing or unable to be read
+	ProductYAML *ProductYAML
+}
+
+// NewExistingSubmission returns the ExistingSubmission in folder with the contents of its PRODUCT.yaml,
+// which may be empty
+func NewExistingSubmission(folder string, productYAMLContents []byte) *ExistingSubmission {
+	existing := &ExistingSubmission{Folder: folder}
+	if len(productYAMLContents) > 0 {
+		if productYAML, err := ParseProductYAML(productYAMLContents); err == nil {
+			existing.ProductYAML = productYAML
+		}
+	}
+	return existing
+}
+
+func (s *PRSuite) theSubmissionDoesNotOverwriteOrDuplicateAnExistingSubmission() error {
+	// existing submissions are only known when the base branch has been looked up
+	if s.PR.ExistingSubmissions == nil {
+		return nil
+	}
+	folder := path.Join(s.KubernetesReleaseVersion, s.ProductName)
+	var productYAML *ProductYAML
+	if file := s.GetFileByFileName("PRODUCT.yaml"); file != nil {
+		productYAML, _ = ParseProductYAML([]byte(file.Contents))
+	}
+
+	problems := []string{}
+	for _, existing := range s.PR.ExistingSubmissions {
+		if existing.Folder == folder {
+			problems = append(problems, fmt.Sprintf("the folder '%v' already exists, so this submission overwrites a certified product", existing.Folder))
+			continue
+		}
+		if isNearDuplicateName(path.Base(existing.Folder), s.ProductName) {
+			problems = append(problems, fmt.Sprintf("the folder '%v' has a name close to '%v'", existing.Folder, folder))
+			continue
+		}
+		if productYAML == nil || existing.ProductYAML == nil {
+			continue
+		}
+		if isNearDuplicateName(existing.ProductYAML.Vendor, productYAML.Vendor) && isNearDuplicateName(existing.ProductYAML.Name, productYAML.Name) {
+			problems = append(problems, fmt.Sprintf("the folder '%v' is the product '%v' by '%v', which is close to '%v' by '%v'", existing.Folder, existing.ProductYAML.Name, existing.ProductYAML.Vendor, productYAML.Name, productYAML.Vendor))
+		}
+	}
+	if len(problems) > 0 {
+		return common.SafeError(fmt.Errorf("the submission may overwrite or duplicate a submission for %v on the base branch, please confirm this is intended: \n    - %v", s.KubernetesReleaseVersion, strings.Join(problems, "\n    - ")))
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package suite
+
+import (
+	"html"
+	"strings"
+	"testing"
+)
+
+func TestTheSubmissionDoesNotOverwriteOrDuplicateAnExistingSubmission(t *testing.T) {
+	type testCase struct {
+		Name                string
+		ExistingSubmissions []*ExistingSubmission
+		ExpectedErrorString string
+	}
+
+	productYAML := `vendor: "Cool Inc."
+name: "CoolKube Enterprise"`
+	for _, tc := range []testCase{
+		{
+			Name: "base branch not looked up",
+		},
+		{
+			Name:                "no existing submissions",
+			ExistingSubmissions: []*ExistingSubmission{},
+		},
+		{
+			Name: "different products",
+			ExistingSubmissions: []*ExistingSubmission{
+				NewExistingSubmission("v1.35/warmkube", []byte(`vendor: "Warm Inc."
+name: "WarmKube"`)),
+				NewExistingSubmission("v1.35/coolkube-lite", []byte(`vendor: "Cool Inc."
+name: "CoolKube Lite"`)),
+				NewExistingSubmission("v1.35/broken", []byte(`{`)),
+			},
+		},
+		{
+			Name: "overwrite",
+			ExistingSubmissions: []*ExistingSubmission{
+				NewExistingSubmission("v1.35/coolkube", nil),
+			},
+			ExpectedErrorString: "the submission may overwrite or duplicate a submission for v1.35 on the base branch, please confirm this is intended: \n" +
+				"    - the folder 'v1.35/coolkube' already exists, so this submission overwrites a certified product",
+		},
+		{
+			Name: "similar folder name",
+			ExistingSubmissions: []*ExistingSubmission{
+				NewExistingSubmission("v1.35/cool-kube", nil),
+			},
+			ExpectedErrorString: "    - the folder 'v1.35/cool-kube' has a name close to 'v1.35/coolkube'",
+		},
+		{
+			Name: "same vendor and product in a different folder",
+			ExistingSubmissions: []*ExistingSubmission{
+				NewExistingSubmission("v1.35/cke", []byte(`vendor: "Cool, Inc"
+name: "Coolkube enterprise"`)),
+			},
+			ExpectedErrorString: "    - the folder 'v1.35/cke' is the product 'Coolkube enterprise' by 'Cool, Inc', which is close to 'CoolKube Enterprise' by 'Cool Inc.'",
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			prSuite := NewPRSuite(&PullRequest{
+				SupportingFiles: []*PullRequestFile{
+					{Name: "v1.35/coolkube/PRODUCT.yaml", BaseName: "PRODUCT.yaml", Contents: productYAML},
+				},
+				ExistingSubmissions: tc.ExistingSubmissions,
+			})
+			prSuite.SetSubmissionMetadatafromFolderStructure()
+			err := prSuite.theSubmissionDoesNotOverwriteOrDuplicateAnExistingSubmission()
+			if tc.ExpectedErrorString == "" && err != nil {
+				t.Fatalf("error: unexpected error: %v", err)
+			}
+			if tc.ExpectedErrorString != "" && (err == nil || !strings.Contains(html.UnescapeString(err.Error()), tc.ExpectedErrorString)) {
+				t.Fatalf("error: expected error containing '%v'; got = %v", tc.ExpectedErrorString, err)
+			}
+		})
+	}
+}
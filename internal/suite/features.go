@@ -0,0 +1,184 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package suite
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+
+	gherkin "github.com/cucumber/gherkin/go/v26"
+	messages "github.com/cucumber/messages/go/v21"
+
+	"sigs.k8s.io/verify-conformance/internal/types"
+)
+
+const (
+	// FeaturesFolder is the folder of the feature files in the data, like kodata/features
+	FeaturesFolder = "features"
+	// ConformanceMetadataFolder is the folder of the conformance.yaml for each release in the data,
+	// like kodata/conformance-testdata
+	ConformanceMetadataFolder = "conformance-testdata"
+)
+
+// Scenario is a scenario from the feature files, as written for submitters
+type Scenario struct {
+	Name        string
+	Description string
+	Steps       []string
+	Examples    [][]string
+}
+
+// GetScenarios reads all scenarios from the feature files found in paths of fsys
+func GetScenarios(fsys fs.FS, paths []string) (scenarios []Scenario, err error) {
+	for _, p := range paths {
+		err := fs.WalkDir(fsys, p, func(filePath string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() || path.Ext(filePath) != ".feature" {
+				return nil
+			}
+			content, err := fs.ReadFile(fsys, filePath)
+			if err != nil {
+				return err
+			}
+			doc, err := gherkin.ParseGherkinDocument(bytes.NewReader(content), (&messages.Incrementing{}).NewId)
+			if err != nil {
+				return fmt.Errorf("unable to parse feature file '%v', %v", filePath, err)
+			}
+			if doc.Feature == nil {
+				return nil
+			}
+			for _, child := range doc.Feature.Children {
+				if child.Scenario == nil {
+					continue
+				}
+				scenarios = append(scenarios, newScenario(child.Scenario))
+			}
+			return nil
+		})
+		if err != nil {
+			return []Scenario{}, err
+		}
+	}
+	return scenarios, nil
+}
+
+// ValidateFeatures checks that the feature files in paths of fsys parse and only use steps which are defined,
+// by running them against an empty PR
+func ValidateFeatures(fsys fs.FS, paths []string) error {
+	scenarios, err := GetScenarios(fsys, paths)
+	if err != nil {
+		return err
+	}
+	if len(scenarios) == 0 {
+		return fmt.Errorf("no scenarios found in %v", strings.Join(paths, ", "))
+	}
+	prSuite := NewPRSuite(&PullRequest{})
+	prSuite.DataFS = fsys
+	prSuite.NewTestSuite(PRSuiteOptions{Paths: paths}).Run()
+	cukeFeatures := []types.CukeFeatureJSON{}
+	if err := json.Unmarshal(prSuite.buffer.Bytes(), &cukeFeatures); err != nil {
+		return fmt.Errorf("unable to read the results of the feature files, %v", err)
+	}
+	undefined := []string{}
+	for _, f := range cukeFeatures {
+		for _, e := range f.Elements {
+			for _, step := range e.Steps {
+				if step.Result.Status == "undefined" {
+					undefined = append(undefined, fmt.Sprintf("'%v %v' in the scenario '%v'", strings.TrimSpace(step.Keyword), step.Name, e.Name))
+				}
+			}
+		}
+	}
+	if len(undefined) > 0 {
+		return fmt.Errorf("the feature files use undefined steps: \n    - %v", strings.Join(undefined, "\n    - "))
+	}
+	return nil
+}
+
+func newScenario(s *messages.Scenario) Scenario {
+	scenario := Scenario{
+		Name:        strings.TrimSpace(s.Name),
+		Description: strings.TrimSpace(s.Description),
+	}
+	for _, step := range s.Steps {
+		scenario.Steps = append(scenario.Steps, strings.TrimSpace(step.Keyword)+" "+step.Text)
+	}
+	for _, e := range s.Examples {
+		rows := e.TableBody
+		if e.TableHeader != nil {
+			rows = append([]*messages.TableRow{e.TableHeader}, rows...)
+		}
+		for _, row := range rows {
+			cells := []string{}
+			for _, c := range row.Cells {
+				cells = append(cells, c.Value)
+			}
+			scenario.Examples = append(scenario.Examples, cells)
+		}
+	}
+	return scenario
+}
+
+// FindScenarios returns the scenarios named exactly like name, or otherwise containing it, ignoring case
+func FindScenarios(scenarios []Scenario, name string) (found []Scenario) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return []Scenario{}
+	}
+	for _, s := range scenarios {
+		if strings.ToLower(s.Name) == name {
+			return []Scenario{s}
+		}
+	}
+	for _, s := range scenarios {
+		if strings.Contains(strings.ToLower(s.Name), name) {
+			found = append(found, s)
+		}
+	}
+	return found
+}
+
+// Markdown renders the scenario with its steps and examples for a comment
+func (s Scenario) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "**Scenario: %v**\n", s.Name)
+	if s.Description != "" {
+		fmt.Fprintf(&b, "\n%v\n", s.Description)
+	}
+	if len(s.Steps) > 0 {
+		b.WriteString("\n")
+		for _, step := range s.Steps {
+			fmt.Fprintf(&b, "- %v\n", step)
+		}
+	}
+	for i, row := range s.Examples {
+		if i == 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "| %v |\n", strings.Join(row, " | "))
+		if i == 0 {
+			fmt.Fprintf(&b, "|%v\n", strings.Repeat(" --- |", len(row)))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package suite
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/verify-conformance/internal/common"
+)
+
+func TestGetScenarios(t *testing.T) {
+	scenarios, err := GetScenarios(common.GetDataFS(), []string{FeaturesFolder})
+	if err != nil {
+		t.Fatalf("error: unexpected error reading scenarios: %v", err)
+	}
+	if len(scenarios) == 0 {
+		t.Fatalf("error: no scenarios found")
+	}
+	found := FindScenarios(scenarios, "PR title is not empty")
+	if len(found) != 1 {
+		t.Fatalf("error: expected to find one scenario; found %v", len(found))
+	}
+	if found[0].Description != "it seems that there is no title set" {
+		t.Fatalf("error: unexpected description '%v'", found[0].Description)
+	}
+	if want := []string{"Given a PR title", "Then the PR title is not empty"}; strings.Join(found[0].Steps, ",") != strings.Join(want, ",") {
+		t.Fatalf("error: unexpected steps %v", found[0].Steps)
+	}
+
+	if _, err := GetScenarios(os.DirFS("."), []string{"testdata/does-not-exist"}); err == nil {
+		t.Fatalf("error: expected error reading a missing path")
+	}
+}
+
+func TestFindScenarios(t *testing.T) {
+	scenarios := []Scenario{
+		{Name: "all tests pass"},
+		{Name: "all tests pass and more"},
+		{Name: "there is only one commit"},
+	}
+	for name, want := range map[string]int{
+		"All tests pass": 1,
+		"tests":          2,
+		"commit":         1,
+		"soup":           0,
+		"":               0,
+	} {
+		if got := len(FindScenarios(scenarios, name)); got != want {
+			t.Fatalf("error: unexpected number of scenarios found for '%v': want = %v; got = %v", name, want, got)
+		}
+	}
+}
+
+func TestScenarioMarkdown(t *testing.T) {
+	md := Scenario{
+		Name:        "submission contains all required files",
+		Description: "there seems to be some required files missing",
+		Steps:       []string{"Given <file> is included in its file list"},
+		Examples:    [][]string{{"file"}, {`"README.md"`}},
+	}.Markdown()
+	want := `**Scenario: submission contains all required files**
+
+there seems to be some required files missing
+
+- Given <file> is included in its file list
+
+| file |
+| --- |
+| "README.md" |
+`
+	if md != want {
+		t.Fatalf("error: unexpected markdown:\n%v", md)
+	}
+}
+
+func TestValidateFeatures(t *testing.T) {
+	if err := ValidateFeatures(common.GetDataFS(), []string{FeaturesFolder}); err != nil {
+		t.Fatalf("error: unexpected error validating the feature files: %v", err)
+	}
+
+	dir := t.TempDir()
+	feature := "Feature: verify conformance product submission PR\n\n  Scenario: PR title is not empty\n    Given a PR title\n    Then the PR title is not emptyish\n"
+	if err := os.WriteFile(filepath.Join(dir, "undefined.feature"), []byte(feature), 0644); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	err := ValidateFeatures(os.DirFS(dir), []string{"."})
+	if err == nil || !strings.Contains(err.Error(), "'Then the PR title is not emptyish' in the scenario 'PR title is not empty'") {
+		t.Fatalf("error: expected an undefined step error; got = %v", err)
+	}
+
+	if err := ValidateFeatures(os.DirFS(t.TempDir()), []string{"."}); err == nil || !strings.Contains(err.Error(), "no scenarios found") {
+		t.Fatalf("error: expected a no scenarios error; got = %v", err)
+	}
+}
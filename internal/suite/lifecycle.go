@@ -0,0 +1,278 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package suite
+
+import (
+	"fmt"
+	"io/fs"
+	"sort"
+	"time"
+
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/verify-conformance/internal/common"
+)
+
+const (
+	// ReleaseDateFormat is the format of the release dates in the release lifecycle
+	ReleaseDateFormat = "2006-01-02"
+	// DefaultSupportedMinors is how many of the newest releases are accepted
+	// when there is no release lifecycle
+	DefaultSupportedMinors = 3
+)
+
+// ReleaseLifecycle describes which Kubernetes releases submissions are accepted for,
+// read from kodata/metadata/release-lifecycle.yaml
+type ReleaseLifecycle struct {
+	// SupportedMinors is how many of the newest releases are accepted
+	SupportedMinors int `json:"supportedMinors"`
+	// GracePeriodDays is how long the release which falls out of the window is still
+	// accepted for after a new release
+	GracePeriodDays int `json:"gracePeriodDays"`
+	// Releases are the known releases and their release dates
+	Releases []ReleaseLifecycleRelease `json:"releases"`
+}
+
+// ReleaseLifecycleRelease is a release and the date it came out, or is planned to
+type ReleaseLifecycleRelease struct {
+	Version     string `json:"version"`
+	ReleaseDate string `json:"releaseDate"`
+}
+
+// ReleaseSupport is whether a release is accepted at a point in time
+type ReleaseSupport struct {
+	Supported bool
+	// Oldest is the oldest release in the support window, not counting the grace period
+	Oldest *ReleaseVersion
+	// Until is when the release stops, or stopped, being accepted, zero when it isn't known
+	Until time.Time
+	// ReplacedBy is the release whose release date starts the grace period ending at Until
+	ReplacedBy *ReleaseVersion
+}
+
+// GetReleaseLifecycle reads the release lifecycle from the data in fsys
+func GetReleaseLifecycle(fsys fs.FS) (*ReleaseLifecycle, error) {
+	content, err := fs.ReadFile(fsys, "metadata/release-lifecycle.yaml")
+	if err != nil {
+		return nil, err
+	}
+	return ParseReleaseLifecycle(content)
+}
+
+// ParseReleaseLifecycle parses and validates a release lifecycle
+func ParseReleaseLifecycle(content []byte) (*ReleaseLifecycle, error) {
+	lifecycle := &ReleaseLifecycle{}
+	if err := yaml.Unmarshal(content, lifecycle); err != nil {
+		return nil, fmt.Errorf("unable to parse the release lifecycle, %v", err)
+	}
+	if lifecycle.SupportedMinors < 1 {
+		return nil, fmt.Errorf("the release lifecycle must support at least one release, found supportedMinors %v", lifecycle.SupportedMinors)
+	}
+	if lifecycle.GracePeriodDays < 0 {
+		return nil, fmt.Errorf("the release lifecycle gracePeriodDays %v must not be negative", lifecycle.GracePeriodDays)
+	}
+	for _, r := range lifecycle.Releases {
+		if _, err := ParseReleaseVersion(r.Version); err != nil {
+			return nil, fmt.Errorf("invalid release in the release lifecycle, %v", err)
+		}
+		if r.ReleaseDate == "" {
+			continue
+		}
+		if _, err := time.Parse(ReleaseDateFormat, r.ReleaseDate); err != nil {
+			return nil, fmt.Errorf("invalid release date '%v' for %v in the release lifecycle, it must be like %v", r.ReleaseDate, r.Version, ReleaseDateFormat)
+		}
+	}
+	return lifecycle, nil
+}
+
+// GracePeriod returns how long the release which falls out of the window is still accepted for
+func (l *ReleaseLifecycle) GracePeriod() time.Duration {
+	return time.Duration(l.GracePeriodDays) * 24 * time.Hour
+}
+
+// releaseDate returns the release date of the minor release of version, if it is known
+func (l *ReleaseLifecycle) releaseDate(version *ReleaseVersion) (time.Time, bool) {
+	for _, r := range l.Releases {
+		v, err := ParseReleaseVersion(r.Version)
+		if err != nil || v.MinorVersion() != version.MinorVersion() || r.ReleaseDate == "" {
+			continue
+		}
+		date, err := time.Parse(ReleaseDateFormat, r.ReleaseDate)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return date, true
+	}
+	return time.Time{}, false
+}
+
+// knownReleases returns the minor releases in the lifecycle and latest, oldest first
+func (l *ReleaseLifecycle) knownReleases(latest *ReleaseVersion) []*ReleaseVersion {
+	seen := map[string]bool{}
+	releases := []*ReleaseVersion{}
+	add := func(version string) {
+		v, err := ParseReleaseVersion(version)
+		if err != nil || seen[v.MinorVersion()] {
+			return
+		}
+		seen[v.MinorVersion()] = true
+		releases = append(releases, &ReleaseVersion{Major: v.Major, Minor: v.Minor})
+	}
+	for _, r := range l.Releases {
+		add(r.Version)
+	}
+	add(latest.MinorVersion())
+	sort.Slice(releases, func(i, j int) bool {
+		return compareMinorVersions(releases[i], releases[j]) < 0
+	})
+	return releases
+}
+
+// latestRelease returns the newest of stable and the releases whose release date has passed
+func (l *ReleaseLifecycle) latestRelease(stable *ReleaseVersion, now time.Time) *ReleaseVersion {
+	latest := &ReleaseVersion{Major: stable.Major, Minor: stable.Minor}
+	for _, r := range l.Releases {
+		v, err := ParseReleaseVersion(r.Version)
+		if err != nil {
+			continue
+		}
+		if date, ok := l.releaseDate(v); ok && !now.Before(date) && compareMinorVersions(v, latest) > 0 {
+			latest = &ReleaseVersion{Major: v.Major, Minor: v.Minor}
+		}
+	}
+	return latest
+}
+
+// minorsBehind returns how many releases version is behind latest, which is only known
+// across major versions when both are in the lifecycle
+func minorsBehind(releases []*ReleaseVersion, version, latest *ReleaseVersion) (int, bool) {
+	if version.Major == latest.Major {
+		return latest.Minor - version.Minor, true
+	}
+	versionIndex, latestIndex := indexOfRelease(releases, version), indexOfRelease(releases, latest)
+	if versionIndex < 0 || latestIndex < 0 {
+		return 0, false
+	}
+	return latestIndex - versionIndex, true
+}
+
+// releaseAfter returns the release which is n releases after version, or before it when n is negative,
+// counting by minor version when it isn't in releases
+func releaseAfter(releases []*ReleaseVersion, version *ReleaseVersion, n int) *ReleaseVersion {
+	if i := indexOfRelease(releases, version); i >= 0 && i+n >= 0 && i+n < len(releases) {
+		return releases[i+n]
+	}
+	return &ReleaseVersion{Major: version.Major, Minor: version.Minor + n}
+}
+
+func indexOfRelease(releases []*ReleaseVersion, version *ReleaseVersion) int {
+	for i, r := range releases {
+		if r.MinorVersion() == version.MinorVersion() {
+			return i
+		}
+	}
+	return -1
+}
+
+func compareMinorVersions(a, b *ReleaseVersion) int {
+	if a.Major != b.Major {
+		return a.Major - b.Major
+	}
+	return a.Minor - b.Minor
+}
+
+// Support returns whether version is accepted at now, given stable as the latest release
+// known from stable.txt.
+// The newest SupportedMinors releases are accepted, along with the release before them
+// until the grace period after the newest release date has passed.
+func (l *ReleaseLifecycle) Support(version, stable *ReleaseVersion, now time.Time) *ReleaseSupport {
+	releases := l.knownReleases(stable)
+	latest := l.latestRelease(stable, now)
+	support := &ReleaseSupport{
+		Oldest: releaseAfter(releases, latest, 1-l.SupportedMinors),
+	}
+	behind, known := minorsBehind(releases, version, latest)
+	if !known {
+		support.Supported = version.Major > latest.Major
+		return support
+	}
+	if behind < l.SupportedMinors {
+		support.Supported = true
+	}
+	// the release which moves version out of the window starts its grace period
+	support.ReplacedBy = releaseAfter(releases, version, l.SupportedMinors)
+	if date, ok := l.releaseDate(support.ReplacedBy); ok {
+		support.Until = date.Add(l.GracePeriod())
+		if behind == l.SupportedMinors && now.Before(support.Until) {
+			support.Supported = true
+		}
+	}
+	return support
+}
+
+// SupportedReleases returns the minor releases accepted at now, oldest first,
+// given stable as the latest release known from stable.txt
+func (l *ReleaseLifecycle) SupportedReleases(stable *ReleaseVersion, now time.Time) []*ReleaseVersion {
+	releases := l.knownReleases(stable)
+	latest := l.latestRelease(stable, now)
+	supported := []*ReleaseVersion{}
+	for n := l.SupportedMinors; n >= 0; n-- {
+		v := releaseAfter(releases, latest, -n)
+		if v.Minor < 0 || !l.Support(v, stable, now).Supported {
+			continue
+		}
+		supported = append(supported, v)
+	}
+	return supported
+}
+
+// releaseLifecycle returns the release lifecycle of the suite,
+// only accepting the newest DefaultSupportedMinors releases when there isn't one
+func (s *PRSuite) releaseLifecycle() *ReleaseLifecycle {
+	if s.ReleaseLifecycle != nil {
+		return s.ReleaseLifecycle
+	}
+	return &ReleaseLifecycle{SupportedMinors: DefaultSupportedMinors}
+}
+
+// releaseSupport returns whether the release version of the submission is accepted now
+func (s *PRSuite) releaseSupport() (*ReleaseSupport, error) {
+	latestVersion, err := ParseReleaseVersion(s.KubernetesReleaseVersionLatest)
+	if err != nil {
+		fmt.Printf("error parsing latestVersion '%v': %v\n", s.KubernetesReleaseVersionLatest, err)
+		return nil, common.SafeError(fmt.Errorf("unable to parse latest release version"))
+	}
+	currentVersion, err := ParseReleaseVersion(s.KubernetesReleaseVersion)
+	if err != nil {
+		fmt.Printf("error parsing currentVersion '%v': %v\n", s.KubernetesReleaseVersion, err)
+		return nil, common.SafeError(fmt.Errorf("unable to parse release version"))
+	}
+	return s.releaseLifecycle().Support(currentVersion, latestVersion, s.Now()), nil
+}
+
+// describeReleaseSupportEnd returns when the release version of the submission stops being accepted,
+// or an empty string when it isn't known
+func (s *PRSuite) describeReleaseSupportEnd(support *ReleaseSupport) string {
+	if support.Until.IsZero() {
+		return ""
+	}
+	verb := "is accepted until"
+	if !s.Now().Before(support.Until) {
+		verb = "stopped being accepted on"
+	}
+	return fmt.Sprintf("%v %v %v, %v days after the release of %v", s.KubernetesReleaseVersion, verb, support.Until.Format(ReleaseDateFormat), s.releaseLifecycle().GracePeriodDays, support.ReplacedBy.MinorVersion())
+}
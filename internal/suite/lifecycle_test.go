@@ -0,0 +1,300 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package suite
+
+import (
+	"html"
+	"strings"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/verify-conformance/internal/common"
+)
+
+var testReleaseLifecycle = &ReleaseLifecycle{
+	SupportedMinors: 3,
+	GracePeriodDays: 60,
+	Releases: []ReleaseLifecycleRelease{
+		{Version: "v1.33", ReleaseDate: "2025-04-23"},
+		{Version: "v1.34", ReleaseDate: "2025-08-27"},
+		{Version: "v1.35", ReleaseDate: "2025-12-17"},
+		{Version: "v1.36", ReleaseDate: "2026-04-22"},
+		{Version: "v1.37"},
+	},
+}
+
+func mustParseReleaseDate(t *testing.T, date string) time.Time {
+	parsed, err := time.Parse(ReleaseDateFormat, date)
+	if err != nil {
+		t.Fatalf("error: unable to parse date '%v': %v", date, err)
+	}
+	return parsed
+}
+
+func TestParseReleaseLifecycle(t *testing.T) {
+	type testCase struct {
+		Name                string
+		Content             string
+		ExpectedErrorString string
+	}
+
+	for _, tc := range []testCase{
+		{
+			Name:    "valid",
+			Content: "supportedMinors: 3\ngracePeriodDays: 60\nreleases:\n  - version: v1.36\n    releaseDate: \"2026-04-22\"\n  - version: v1.37\n",
+		},
+		{
+			Name:                "no supported minors",
+			Content:             "gracePeriodDays: 60\n",
+			ExpectedErrorString: "must support at least one release",
+		},
+		{
+			Name:                "negative grace period",
+			Content:             "supportedMinors: 3\ngracePeriodDays: -1\n",
+			ExpectedErrorString: "must not be negative",
+		},
+		{
+			Name:                "invalid version",
+			Content:             "supportedMinors: 3\nreleases:\n  - version: 1.36\n",
+			ExpectedErrorString: "invalid release in the release lifecycle",
+		},
+		{
+			Name:                "invalid release date",
+			Content:             "supportedMinors: 3\nreleases:\n  - version: v1.36\n    releaseDate: 22/04/2026\n",
+			ExpectedErrorString: "invalid release date '22/04/2026' for v1.36",
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, err := ParseReleaseLifecycle([]byte(tc.Content))
+			if tc.ExpectedErrorString == "" && err != nil {
+				t.Fatalf("error: unexpected error: %v", err)
+			}
+			if tc.ExpectedErrorString != "" && (err == nil || !strings.Contains(err.Error(), tc.ExpectedErrorString)) {
+				t.Fatalf("error: expected error containing '%v'; got = %v", tc.ExpectedErrorString, err)
+			}
+		})
+	}
+}
+
+func TestGetReleaseLifecycle(t *testing.T) {
+	lifecycle, err := GetReleaseLifecycle(common.GetDataFS())
+	if err != nil {
+		t.Fatalf("error: unable to read the release lifecycle: %v", err)
+	}
+	if lifecycle.SupportedMinors < 1 || len(lifecycle.Releases) == 0 {
+		t.Fatalf("error: unexpected release lifecycle: %+v", lifecycle)
+	}
+}
+
+func TestReleaseLifecycleSupport(t *testing.T) {
+	type testCase struct {
+		Name              string
+		Version           string
+		Stable            string
+		Now               string
+		ExpectedSupported bool
+		ExpectedOldest    string
+		ExpectedUntil     string
+	}
+
+	for _, tc := range []testCase{
+		{
+			Name:              "latest release",
+			Version:           "v1.36",
+			Stable:            "v1.36.0",
+			Now:               "2026-05-01",
+			ExpectedSupported: true,
+			ExpectedOldest:    "v1.34",
+		},
+		{
+			Name:              "oldest release in the window",
+			Version:           "v1.34",
+			Stable:            "v1.36.0",
+			Now:               "2026-05-01",
+			ExpectedSupported: true,
+			ExpectedOldest:    "v1.34",
+		},
+		{
+			Name:              "N-3 in the grace period",
+			Version:           "v1.33",
+			Stable:            "v1.36.0",
+			Now:               "2026-05-01",
+			ExpectedSupported: true,
+			ExpectedOldest:    "v1.34",
+			ExpectedUntil:     "2026-06-21",
+		},
+		{
+			Name:           "N-3 after the grace period",
+			Version:        "v1.33",
+			Stable:         "v1.36.0",
+			Now:            "2026-06-21",
+			ExpectedOldest: "v1.34",
+			ExpectedUntil:  "2026-06-21",
+		},
+		{
+			Name:              "N-3 before stable.txt is bumped",
+			Version:           "v1.33",
+			Stable:            "v1.35.2",
+			Now:               "2026-05-01",
+			ExpectedSupported: true,
+			ExpectedOldest:    "v1.34",
+			ExpectedUntil:     "2026-06-21",
+		},
+		{
+			Name:           "N-4",
+			Version:        "v1.32",
+			Stable:         "v1.36.0",
+			Now:            "2026-05-01",
+			ExpectedOldest: "v1.34",
+			ExpectedUntil:  "2026-02-15",
+		},
+		{
+			Name:              "N-3 of a release without a release date",
+			Version:           "v1.34",
+			Stable:            "v1.37.0",
+			Now:               "2026-09-01",
+			ExpectedSupported: false,
+			ExpectedOldest:    "v1.35",
+		},
+		{
+			Name:              "future release",
+			Version:           "v1.208",
+			Stable:            "v1.36.0",
+			Now:               "2026-05-01",
+			ExpectedSupported: true,
+			ExpectedOldest:    "v1.34",
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			version, err := ParseReleaseVersion(tc.Version)
+			if err != nil {
+				t.Fatalf("error: %v", err)
+			}
+			stable, err := ParseReleaseVersion(tc.Stable)
+			if err != nil {
+				t.Fatalf("error: %v", err)
+			}
+			support := testReleaseLifecycle.Support(version, stable, mustParseReleaseDate(t, tc.Now))
+			if support.Supported != tc.ExpectedSupported {
+				t.Fatalf("error: unexpected support; want = %v; got = %v", tc.ExpectedSupported, support.Supported)
+			}
+			if support.Oldest.MinorVersion() != tc.ExpectedOldest {
+				t.Fatalf("error: unexpected oldest release; want = %v; got = %v", tc.ExpectedOldest, support.Oldest)
+			}
+			until := ""
+			if !support.Until.IsZero() {
+				until = support.Until.Format(ReleaseDateFormat)
+			}
+			if until != tc.ExpectedUntil {
+				t.Fatalf("error: unexpected end of support; want = %v; got = %v", tc.ExpectedUntil, until)
+			}
+		})
+	}
+}
+
+func TestReleaseLifecycleSupportedReleases(t *testing.T) {
+	type testCase struct {
+		Name     string
+		Stable   string
+		Now      string
+		Expected []string
+	}
+
+	for _, tc := range []testCase{
+		{
+			Name:     "in the grace period",
+			Stable:   "v1.36.0",
+			Now:      "2026-05-01",
+			Expected: []string{"v1.33", "v1.34", "v1.35", "v1.36"},
+		},
+		{
+			Name:     "after the grace period",
+			Stable:   "v1.36.0",
+			Now:      "2026-10-17",
+			Expected: []string{"v1.34", "v1.35", "v1.36"},
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			stable, err := ParseReleaseVersion(tc.Stable)
+			if err != nil {
+				t.Fatalf("error: %v", err)
+			}
+			releases := []string{}
+			for _, r := range testReleaseLifecycle.SupportedReleases(stable, mustParseReleaseDate(t, tc.Now)) {
+				releases = append(releases, r.MinorVersion())
+			}
+			if strings.Join(releases, ",") != strings.Join(tc.Expected, ",") {
+				t.Fatalf("error: unexpected releases; want = %v; got = %v", tc.Expected, releases)
+			}
+		})
+	}
+}
+
+func TestItIsAValidAndSupportedReleaseWithReleaseLifecycle(t *testing.T) {
+	type testCase struct {
+		Name                string
+		Version             string
+		Now                 string
+		ExpectedErrorString string
+		ExpectedEnd         string
+	}
+
+	for _, tc := range []testCase{
+		{
+			Name:        "in the grace period",
+			Version:     "v1.33",
+			Now:         "2026-05-01",
+			ExpectedEnd: "v1.33 is accepted until 2026-06-21, 60 days after the release of v1.36",
+		},
+		{
+			Name:                "after the grace period",
+			Version:             "v1.33",
+			Now:                 "2026-07-01",
+			ExpectedErrorString: "unable to use version v1.33 because it is older than the last currently supported release v1.34; v1.33 stopped being accepted on 2026-06-21, 60 days after the release of v1.36",
+		},
+		{
+			Name:    "without an end of support",
+			Version: "v1.36",
+			Now:     "2026-05-01",
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			prSuite := NewPRSuite(&PullRequest{})
+			prSuite.KubernetesReleaseVersion = tc.Version
+			prSuite.KubernetesReleaseVersionLatest = "v1.36.0"
+			prSuite.ReleaseLifecycle = testReleaseLifecycle
+			prSuite.Now = func() time.Time { return mustParseReleaseDate(t, tc.Now) }
+			err := prSuite.itIsAValidAndSupportedRelease()
+			if tc.ExpectedErrorString == "" && err != nil {
+				t.Fatalf("error: unexpected error: %v", err)
+			}
+			if tc.ExpectedErrorString != "" && (err == nil || html.UnescapeString(err.Error()) != tc.ExpectedErrorString) {
+				t.Fatalf("error: unexpected error;\nwant = %v\ngot  = %v", tc.ExpectedErrorString, err)
+			}
+			if tc.ExpectedErrorString != "" {
+				return
+			}
+			support, err := prSuite.releaseSupport()
+			if err != nil {
+				t.Fatalf("error: %v", err)
+			}
+			if end := prSuite.describeReleaseSupportEnd(support); end != tc.ExpectedEnd {
+				t.Fatalf("error: unexpected end of support;\nwant = %v\ngot  = %v", tc.ExpectedEnd, end)
+			}
+		})
+	}
+}
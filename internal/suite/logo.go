@@ -0,0 +1,153 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package suite
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"sigs.k8s.io/verify-conformance/internal/common"
+)
+
+const (
+	// MaxProductLogoSize is the largest product logo which is downloaded, in bytes
+	MaxProductLogoSize = 1024 * 1024
+	// ProductLogoContentType is the content type the product logo must be served with
+	ProductLogoContentType = "image/svg+xml"
+)
+
+var (
+	// svgDangerousElements run code or embed other documents
+	svgDangerousElements = []string{"script", "foreignobject", "iframe", "embed", "object", "handler", "listener"}
+	// svgReferenceAttributes refer to other resources
+	svgReferenceAttributes = []string{"href", "src"}
+	// svgSafeDataURIRegexp matches inline raster images, which are unable to run code
+	svgSafeDataURIRegexp = regexp.MustCompile(`^data:image/(png|jpeg|gif|webp)[;,]`)
+	// cssExternalReferenceRegexp matches references to other resources in CSS,
+	// which aren't to a fragment of the same document
+	cssExternalReferenceRegexp = regexp.MustCompile(`(?i)(@import|url\(\s*['"]?[^#'"\s)])`)
+)
+
+// ProductLogo is the product logo from the product_logo_url in PRODUCT.yaml, as downloaded
+type ProductLogo struct {
+	URL         string
+	ContentType string
+	Contents    []byte
+	// Error is why the logo was unable to be downloaded
+	Error string
+}
+
+// ValidateSVG checks that contents is a well-formed SVG without scripts, event handlers or
+// references to other resources, returning every problem found.
+// An error is returned when contents is not well-formed XML.
+func ValidateSVG(contents []byte) (problems []string, err error) {
+	decoder := xml.NewDecoder(bytes.NewReader(contents))
+	root := ""
+	inStyle := false
+	addProblem := func(problem string) {
+		for _, p := range problems {
+			if p == problem {
+				return
+			}
+		}
+		problems = append(problems, problem)
+	}
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := token.(type) {
+		case xml.Directive:
+			if strings.Contains(strings.ToUpper(string(t)), "<!ENTITY") {
+				addProblem("entity declarations in the DOCTYPE")
+			}
+		case xml.StartElement:
+			name := strings.ToLower(t.Name.Local)
+			if root == "" {
+				root = name
+				if root != "svg" {
+					return []string{fmt.Sprintf("the root element is <%v>, expected <svg>", t.Name.Local)}, nil
+				}
+			}
+			for _, e := range svgDangerousElements {
+				if name == e {
+					addProblem(fmt.Sprintf("a <%v> element", t.Name.Local))
+				}
+			}
+			inStyle = name == "style"
+			for _, attr := range t.Attr {
+				attrName := strings.ToLower(attr.Name.Local)
+				value := strings.TrimSpace(attr.Value)
+				if strings.HasPrefix(attrName, "on") {
+					addProblem(fmt.Sprintf("an event handler attribute '%v' on <%v>", attr.Name.Local, t.Name.Local))
+				}
+				for _, a := range svgReferenceAttributes {
+					if attrName == a && value != "" && !strings.HasPrefix(value, "#") && !svgSafeDataURIRegexp.MatchString(value) {
+						addProblem(fmt.Sprintf("an external reference '%v' in the '%v' attribute of <%v>", value, attr.Name.Local, t.Name.Local))
+					}
+				}
+				if attrName == "style" && cssExternalReferenceRegexp.MatchString(value) {
+					addProblem(fmt.Sprintf("an external reference in the style attribute of <%v>", t.Name.Local))
+				}
+			}
+		case xml.EndElement:
+			inStyle = false
+		case xml.CharData:
+			if inStyle && cssExternalReferenceRegexp.Match(t) {
+				addProblem("an external reference in a <style> element")
+			}
+		}
+	}
+	if root == "" {
+		return nil, fmt.Errorf("no root element found")
+	}
+	return problems, nil
+}
+
+func (s *PRSuite) theProductLogoIsASafeSVG() error {
+	productYAML, err := s.getProductYAML()
+	if err != nil {
+		return err
+	}
+	logo := s.PR.ProductLogo
+	// the product logo is optional, and only downloaded when URLs are resolved
+	if productYAML.ProductLogoURL == "" || logo == nil {
+		return nil
+	}
+	if logo.Error != "" {
+		return common.SafeError(fmt.Errorf("unable to download the product_logo_url '%v' in PRODUCT.yaml, %v", logo.URL, logo.Error))
+	}
+	if !strings.Contains(logo.ContentType, ProductLogoContentType) {
+		return common.SafeError(fmt.Errorf("the product_logo_url '%v' in PRODUCT.yaml resolves content type '%v', which must be (%v)", logo.URL, logo.ContentType, ProductLogoContentType))
+	}
+	problems, err := ValidateSVG(logo.Contents)
+	if err != nil {
+		return common.SafeError(fmt.Errorf("failed to parse the product logo SVG from '%v', %v", logo.URL, err))
+	}
+	if len(problems) > 0 {
+		return common.SafeError(fmt.Errorf("the product logo SVG from '%v' contains the following unsafe content: \n    - %v", logo.URL, strings.Join(problems, "\n    - ")))
+	}
+	return nil
+}
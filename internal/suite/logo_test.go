@@ -0,0 +1,167 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package suite
+
+import (
+	"html"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testSVG = `<?xml version="1.0" encoding="UTF-8"?>
+<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink" viewBox="0 0 10 10">
+  <defs><linearGradient id="cool"><stop offset="0" stop-color="#326ce5"/></linearGradient></defs>
+  <style>.a { fill: url(#cool); }</style>
+  <rect class="a" width="10" height="10"/>
+  <use xlink:href="#cool"/>
+  <image href="data:image/png;base64,iVBORw0KGgo="/>
+</svg>`
+
+func TestValidateSVG(t *testing.T) {
+	type testCase struct {
+		Name                string
+		Contents            string
+		ExpectedProblems    []string
+		ExpectedErrorString string
+	}
+
+	for _, tc := range []testCase{
+		{
+			Name:     "safe svg",
+			Contents: testSVG,
+		},
+		{
+			Name: "unsafe svg",
+			Contents: `<!DOCTYPE svg [<!ENTITY cool "kube">]>
+<svg xmlns="http://www.w3.org/2000/svg" onload="alert(1)">
+  <script>alert(1)</script>
+  <script>alert(2)</script>
+  <style>@import "https://example.com/cool.css";</style>
+  <image href="https://example.com/cool.png"/>
+  <a href="javascript:alert(1)"><rect style="fill: url('https://example.com/cool.svg')" onclick="alert(1)"/></a>
+  <foreignObject><div xmlns="http://www.w3.org/1999/xhtml">cool</div></foreignObject>
+</svg>`,
+			ExpectedProblems: []string{
+				"entity declarations in the DOCTYPE",
+				"an event handler attribute 'onload' on <svg>",
+				"a <script> element",
+				"an external reference in a <style> element",
+				"an external reference 'https://example.com/cool.png' in the 'href' attribute of <image>",
+				"an external reference 'javascript:alert(1)' in the 'href' attribute of <a>",
+				"an external reference in the style attribute of <rect>",
+				"an event handler attribute 'onclick' on <rect>",
+				"a <foreignObject> element",
+			},
+		},
+		{
+			Name:             "not an svg",
+			Contents:         `<html><body>cool</body></html>`,
+			ExpectedProblems: []string{"the root element is <html>, expected <svg>"},
+		},
+		{
+			Name:                "malformed",
+			Contents:            `<svg><rect></svg>`,
+			ExpectedErrorString: "element <rect> closed by </svg>",
+		},
+		{
+			Name:                "png",
+			Contents:            "\x89PNG\r\n\x1a\n",
+			ExpectedErrorString: "invalid UTF-8",
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			problems, err := ValidateSVG([]byte(tc.Contents))
+			if tc.ExpectedErrorString != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.ExpectedErrorString) {
+					t.Fatalf("error: expected error containing '%v'; got = %v", tc.ExpectedErrorString, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error: unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(problems, tc.ExpectedProblems) {
+				t.Fatalf("error: unexpected problems;\nwant = %v\ngot  = %v", strings.Join(tc.ExpectedProblems, "\n       "), strings.Join(problems, "\n       "))
+			}
+		})
+	}
+}
+
+func TestTheProductLogoIsASafeSVG(t *testing.T) {
+	type testCase struct {
+		Name                string
+		ProductYAML         string
+		ProductLogo         *ProductLogo
+		ExpectedErrorString string
+	}
+
+	productYAML := testProductYAML + "\nproduct_logo_url: \"https://coolkubernetes.com/logo.svg\""
+	for _, tc := range []testCase{
+		{
+			Name:        "safe logo",
+			ProductYAML: productYAML,
+			ProductLogo: &ProductLogo{URL: "https://coolkubernetes.com/logo.svg", ContentType: "image/svg+xml", Contents: []byte(testSVG)},
+		},
+		{
+			Name:        "no logo",
+			ProductYAML: testProductYAML,
+		},
+		{
+			Name:        "logo not downloaded",
+			ProductYAML: productYAML,
+		},
+		{
+			Name:                "unable to download",
+			ProductYAML:         productYAML,
+			ProductLogo:         &ProductLogo{URL: "https://coolkubernetes.com/logo.svg", Error: "the server responded with status 404 Not Found"},
+			ExpectedErrorString: "unable to download the product_logo_url 'https://coolkubernetes.com/logo.svg' in PRODUCT.yaml, the server responded with status 404 Not Found",
+		},
+		{
+			Name:                "png logo",
+			ProductYAML:         productYAML,
+			ProductLogo:         &ProductLogo{URL: "https://coolkubernetes.com/logo.svg", ContentType: "image/png", Contents: []byte("\x89PNG")},
+			ExpectedErrorString: "resolves content type 'image/png', which must be (image/svg+xml)",
+		},
+		{
+			Name:                "malformed logo",
+			ProductYAML:         productYAML,
+			ProductLogo:         &ProductLogo{URL: "https://coolkubernetes.com/logo.svg", ContentType: "image/svg+xml", Contents: []byte("<svg>")},
+			ExpectedErrorString: "failed to parse the product logo SVG",
+		},
+		{
+			Name:                "unsafe logo",
+			ProductYAML:         productYAML,
+			ProductLogo:         &ProductLogo{URL: "https://coolkubernetes.com/logo.svg", ContentType: "image/svg+xml", Contents: []byte(`<svg><script>alert(1)</script></svg>`)},
+			ExpectedErrorString: "contains the following unsafe content: \n    - a <script> element",
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			prSuite := NewPRSuite(&PullRequest{
+				SupportingFiles: []*PullRequestFile{{BaseName: "PRODUCT.yaml", Contents: tc.ProductYAML}},
+				ProductLogo:     tc.ProductLogo,
+			})
+			err := prSuite.theProductLogoIsASafeSVG()
+			if tc.ExpectedErrorString == "" && err != nil {
+				t.Fatalf("error: unexpected error: %v", err)
+			}
+			if tc.ExpectedErrorString != "" && (err == nil || !strings.Contains(html.UnescapeString(err.Error()), tc.ExpectedErrorString)) {
+				t.Fatalf("error: expected error containing '%v'; got = %v", tc.ExpectedErrorString, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package suite
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"sigs.k8s.io/verify-conformance/internal/common"
+)
+
+// minContainedNameLength is the shortest name which may match as part of a longer name,
+// so that generic names like "k8s", "kube" or "cloud" don't match almost anything
+const minContainedNameLength = 6
+
+// normalizeName returns name in lower case with only letters and digits, so that
+// names like "Cool Kube" and "cool-kube" are the same
+func normalizeName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, name)
+}
+
+// isNearDuplicateName returns whether a and b are the same name apart from
+// formatting or a typo, allowing an edit for every eight characters
+func isNearDuplicateName(a, b string) bool {
+	a, b = normalizeName(a), normalizeName(b)
+	if a == "" || b == "" {
+		return false
+	}
+	shortest := min(len(a), len(b))
+	return levenshteinDistance(a, b) <= shortest/8
+}
+
+// nameTokens returns the words of name in lower case, split on anything other than letters and digits
+func nameTokens(name string) []string {
+	return strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// containsNameOnTokenBoundaries returns whether part is a run of whole words of name, ignoring formatting,
+// such as "coolkube" or "Cool Kube" in "Cool Kube Enterprise" but not "kube" in "CoolKube"
+func containsNameOnTokenBoundaries(name, part string) bool {
+	normalizedPart := normalizeName(part)
+	if len(normalizedPart) < minContainedNameLength {
+		return false
+	}
+	tokens := nameTokens(name)
+	for i := range tokens {
+		run := ""
+		for _, token := range tokens[i:] {
+			run += token
+			if len(run) >= len(normalizedPart) {
+				break
+			}
+		}
+		if run == normalizedPart {
+			return true
+		}
+	}
+	return false
+}
+
+// isSameProductName returns whether name refers to one of candidates, being a near duplicate
+// or a shortened form, such as a folder named "coolkube" for the product "CoolKube Enterprise"
+func isSameProductName(name string, candidates ...string) bool {
+	for _, c := range candidates {
+		if isNearDuplicateName(name, c) || containsNameOnTokenBoundaries(c, name) || containsNameOnTokenBoundaries(name, c) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *PRSuite) theProductNameInTheTitleFolderStructureAndProductYamlMatch() error {
+	prefix, titleReleaseVersion, titleProductName := s.titleParts()
+	// a title without a product name is reported by the scenario on the format of the title
+	if titleProductName == "" || s.ProductName == "" {
+		return nil
+	}
+	var productYAML *ProductYAML
+	if file := s.GetFileByFileName("PRODUCT.yaml"); file != nil {
+		productYAML, _ = ParseProductYAML([]byte(file.Contents))
+	}
+	names := []string{s.ProductName}
+	suggestedName := s.ProductName
+	if productYAML != nil && productYAML.Name != "" {
+		names = append(names, productYAML.Name, productYAML.Vendor+" "+productYAML.Name)
+		suggestedName = productYAML.Name
+	}
+
+	mismatches := []string{}
+	if !isSameProductName(titleProductName, names...) {
+		mismatches = append(mismatches, fmt.Sprintf("the product name in the title ('%v') does not match %v", titleProductName, describeProductNames(s.ProductName, productYAML)))
+	}
+	// a folder may also be named after the vendor
+	if productYAML != nil && productYAML.Name != "" && !isSameProductName(s.ProductName, append(names[1:], productYAML.Vendor)...) {
+		mismatches = append(mismatches, fmt.Sprintf("the folder name ('%v') does not match the name ('%v') or vendor ('%v') in PRODUCT.yaml", s.ProductName, productYAML.Name, productYAML.Vendor))
+	}
+	if len(mismatches) > 0 {
+		// the title keeps its release version and is formatted like "Conformance results for v1.35/CoolKube"
+		return common.SafeError(fmt.Errorf("the product name is not consistent across the submission: \n    - %v\n\nthe title may be corrected to '%v %v/%v'", strings.Join(mismatches, "\n    - "), prefix, titleReleaseVersion, suggestedName))
+	}
+	return nil
+}
+
+// describeProductNames returns the product names from the folder and PRODUCT.yaml for an error
+func describeProductNames(folderName string, productYAML *ProductYAML) string {
+	if productYAML == nil || productYAML.Name == "" {
+		return fmt.Sprintf("the folder name ('%v')", folderName)
+	}
+	return fmt.Sprintf("the folder name ('%v') or the name in PRODUCT.yaml ('%v')", folderName, productYAML.Name)
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package suite
+
+import (
+	"html"
+	"strings"
+	"testing"
+
+	githubql "github.com/shurcooL/githubv4"
+)
+
+func TestIsNearDuplicateName(t *testing.T) {
+	for _, tc := range []struct {
+		A, B     string
+		Expected bool
+	}{
+		{A: "coolkube", B: "coolkube", Expected: true},
+		{A: "CoolKube", B: "cool-kube", Expected: true},
+		{A: "CoolKube Enterprise", B: "Coolkube Enterprize", Expected: true},
+		{A: "coolkube", B: "coolkub", Expected: false},
+		{A: "k3s", B: "k0s", Expected: false},
+		{A: "coolkube", B: "warmkube", Expected: false},
+		{A: "", B: "", Expected: false},
+	} {
+		t.Run(tc.A+" "+tc.B, func(t *testing.T) {
+			if got := isNearDuplicateName(tc.A, tc.B); got != tc.Expected {
+				t.Fatalf("error: unexpected result for '%v' and '%v'; want = %v; got = %v", tc.A, tc.B, tc.Expected, got)
+			}
+		})
+	}
+}
+
+func TestIsSameProductName(t *testing.T) {
+	for _, tc := range []struct {
+		Name       string
+		Candidates []string
+		Expected   bool
+	}{
+		{Name: "cool-kube", Candidates: []string{"CoolKube"}, Expected: true},
+		{Name: "coolkube", Candidates: []string{"CoolKube Enterprise"}, Expected: true},
+		{Name: "Cool Kube", Candidates: []string{"Cool Kube Enterprise"}, Expected: true},
+		{Name: "CoolKube Enterprise", Candidates: []string{"enterprise"}, Expected: true},
+		{Name: "warmkube", Candidates: []string{"CoolKube", "Cool Inc. CoolKube"}, Expected: false},
+		{Name: "kube", Candidates: []string{"CoolKube Kube"}, Expected: false},
+		{Name: "k8s", Candidates: []string{"Cool k8s"}, Expected: false},
+		{Name: "cloud", Candidates: []string{"Cool Cloud Platform"}, Expected: false},
+		{Name: "c", Candidates: []string{"CoolKube"}, Expected: false},
+		{Name: "kube", Candidates: []string{"coolkube"}, Expected: false},
+		{Name: "", Candidates: []string{"CoolKube"}, Expected: false},
+	} {
+		t.Run(tc.Name+" "+strings.Join(tc.Candidates, ","), func(t *testing.T) {
+			if got := isSameProductName(tc.Name, tc.Candidates...); got != tc.Expected {
+				t.Fatalf("error: unexpected result for '%v' and %v; want = %v; got = %v", tc.Name, tc.Candidates, tc.Expected, got)
+			}
+		})
+	}
+}
+
+func TestTheProductNameInTheTitleFolderStructureAndProductYamlMatch(t *testing.T) {
+	type testCase struct {
+		Name                string
+		Title               string
+		Folder              string
+		ProductYAML         string
+		ExpectedErrorString string
+	}
+
+	for _, tc := range []testCase{
+		{
+			Name:        "same names",
+			Title:       "Conformance results for v1.35/coolkube",
+			Folder:      "coolkube",
+			ProductYAML: "vendor: Cool\nname: coolkube",
+		},
+		{
+			Name:        "names differing in formatting",
+			Title:       "Conformance results for v1.35 Cool Kube Enterprise",
+			Folder:      "coolkube-enterprise",
+			ProductYAML: "vendor: Cool Inc.\nname: CoolKube Enterprise",
+		},
+		{
+			Name:        "title with the vendor",
+			Title:       "Conformance results for v1.35 Cool Inc. Engine",
+			Folder:      "engine",
+			ProductYAML: "vendor: Cool Inc.\nname: Engine",
+		},
+		{
+			Name:        "folder named after the vendor",
+			Title:       "Conformance results for v1.35/Engine",
+			Folder:      "cool-inc",
+			ProductYAML: "vendor: Cool Inc.\nname: Engine",
+		},
+		{
+			Name:   "no PRODUCT.yaml",
+			Title:  "Conformance results for v1.35/coolkube",
+			Folder: "coolkube",
+		},
+		{
+			Name:   "title without a product name",
+			Title:  "Conformance results",
+			Folder: "coolkube",
+		},
+		{
+			Name:        "different title",
+			Title:       "Conformance results for v1.35/warmkube",
+			Folder:      "coolkube",
+			ProductYAML: "vendor: Cool Inc.\nname: CoolKube",
+			ExpectedErrorString: "the product name is not consistent across the submission: \n" +
+				"    - the product name in the title ('warmkube') does not match the folder name ('coolkube') or the name in PRODUCT.yaml ('CoolKube')\n\n" +
+				"the title may be corrected to 'Conformance results for v1.35/CoolKube'",
+		},
+		{
+			Name:        "generic name in the title",
+			Title:       "Conformance results for v1.35/kube",
+			Folder:      "coolkube",
+			ProductYAML: "vendor: Cool Inc.\nname: CoolKube",
+			ExpectedErrorString: "    - the product name in the title ('kube') does not match the folder name ('coolkube') or the name in PRODUCT.yaml ('CoolKube')\n\n" +
+				"the title may be corrected to 'Conformance results for v1.35/CoolKube'",
+		},
+		{
+			Name:                "different title with a patch release",
+			Title:               "Conformance results for v1.35.2 warmkube",
+			Folder:              "coolkube",
+			ProductYAML:         "vendor: Cool Inc.\nname: CoolKube",
+			ExpectedErrorString: "the title may be corrected to 'Conformance results for v1.35.2/CoolKube'",
+		},
+		{
+			Name:        "different folder",
+			Title:       "Conformance results for v1.35 CoolKube",
+			Folder:      "k8s",
+			ProductYAML: "vendor: Cool Inc.\nname: CoolKube",
+			ExpectedErrorString: "    - the folder name ('k8s') does not match the name ('CoolKube') or vendor ('Cool Inc.') in PRODUCT.yaml\n\n" +
+				"the title may be corrected to 'Conformance results for v1.35/CoolKube'",
+		},
+		{
+			Name:                "different title without PRODUCT.yaml",
+			Title:               "Conformance results for v1.35/warmkube",
+			Folder:              "coolkube",
+			ExpectedErrorString: "    - the product name in the title ('warmkube') does not match the folder name ('coolkube')\n\nthe title may be corrected to 'Conformance results for v1.35/coolkube'",
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			files := []*PullRequestFile{{Name: "v1.35/" + tc.Folder + "/README.md", BaseName: "README.md"}}
+			if tc.ProductYAML != "" {
+				files = append(files, &PullRequestFile{Name: "v1.35/" + tc.Folder + "/PRODUCT.yaml", BaseName: "PRODUCT.yaml", Contents: tc.ProductYAML})
+			}
+			prSuite := NewPRSuite(&PullRequest{SupportingFiles: files})
+			prSuite.PR.Title = githubql.String(tc.Title)
+			prSuite.SetSubmissionMetadatafromFolderStructure()
+			err := prSuite.theProductNameInTheTitleFolderStructureAndProductYamlMatch()
+			if tc.ExpectedErrorString == "" && err != nil {
+				t.Fatalf("error: unexpected error: %v", err)
+			}
+			if tc.ExpectedErrorString != "" && (err == nil || !strings.Contains(html.UnescapeString(err.Error()), tc.ExpectedErrorString)) {
+				t.Fatalf("error: expected error containing '%v'; got = %v", tc.ExpectedErrorString, err)
+			}
+			if err == nil {
+				return
+			}
+			// the suggested title is accepted by the scenario on the format of the title
+			_, suggestedTitle, _ := strings.Cut(html.UnescapeString(err.Error()), "the title may be corrected to '")
+			suggestedTitle = strings.TrimSuffix(suggestedTitle, "'")
+			prefix, releaseVersion, _ := prSuite.titleParts()
+			prSuite.PR.Title = githubql.String(suggestedTitle)
+			if err := prSuite.theTitleOfThePRMatches("(.*) ({release})[ /](.*)"); err != nil {
+				t.Fatalf("error: expected the suggested title '%v' to match the format of the title: %v", suggestedTitle, err)
+			}
+			if suggestedPrefix, suggestedReleaseVersion, _ := prSuite.titleParts(); suggestedPrefix != prefix || suggestedReleaseVersion.String() != releaseVersion.String() {
+				t.Fatalf("error: expected the suggested title '%v' to keep '%v %v'", suggestedTitle, prefix, releaseVersion)
+			}
+		})
+	}
+}
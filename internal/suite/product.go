@@ -0,0 +1,297 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package suite
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	yamlv3 "go.yaml.in/yaml/v3"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/verify-conformance/internal/common"
+)
+
+const (
+	// ProductYAMLSchemaFileName is the versioned JSON Schema of PRODUCT.yaml in the schemas folder of kodata
+	ProductYAMLSchemaFileName = "product-yaml-v1.schema.json"
+)
+
+// ProductYAML is the metadata of the product in the PRODUCT.yaml of a submission
+type ProductYAML struct {
+	Vendor              string `json:"vendor"`
+	Name                string `json:"name"`
+	Version             string `json:"version"`
+	Type                string `json:"type"`
+	Description         string `json:"description"`
+	WebsiteURL          string `json:"website_url"`
+	RepoURL             string `json:"repo_url,omitempty"`
+	DocumentationURL    string `json:"documentation_url"`
+	ProductLogoURL      string `json:"product_logo_url,omitempty"`
+	ContactEmailAddress string `json:"contact_email_address"`
+}
+
+// ParseProductYAML returns the ProductYAML in contents, ignoring any unknown fields.
+// An unquoted version like 4.16 or 1.0 is read as it is written
+func ParseProductYAML(contents []byte) (*ProductYAML, error) {
+	contents, err := quoteProductYAMLVersion(contents)
+	if err != nil {
+		return nil, err
+	}
+	productYAML := &ProductYAML{}
+	if err := yaml.Unmarshal(contents, productYAML); err != nil {
+		return nil, err
+	}
+	return productYAML, nil
+}
+
+// quoteProductYAMLVersion returns contents with a numeric version as a string,
+// keeping it as written rather than as a number, where 4.10 would become 4.1
+func quoteProductYAMLVersion(contents []byte) ([]byte, error) {
+	document := &yamlv3.Node{}
+	if err := yamlv3.Unmarshal(contents, document); err != nil {
+		return nil, err
+	}
+	if document.Kind != yamlv3.DocumentNode || len(document.Content) == 0 || document.Content[0].Kind != yamlv3.MappingNode {
+		return contents, nil
+	}
+	fields := document.Content[0].Content
+	for i := 0; i+1 < len(fields); i += 2 {
+		value := fields[i+1]
+		if fields[i].Value != "version" || value.Kind != yamlv3.ScalarNode || (value.Tag != "!!int" && value.Tag != "!!float") {
+			continue
+		}
+		value.Tag, value.Style = "!!str", yamlv3.DoubleQuotedStyle
+		return yamlv3.Marshal(document)
+	}
+	return contents, nil
+}
+
+// Fields returns the values of the fields by their name in PRODUCT.yaml
+func (p *ProductYAML) Fields() map[string]string {
+	return map[string]string{
+		"vendor":                p.Vendor,
+		"name":                  p.Name,
+		"version":               p.Version,
+		"type":                  p.Type,
+		"description":           p.Description,
+		"website_url":           p.WebsiteURL,
+		"repo_url":              p.RepoURL,
+		"documentation_url":     p.DocumentationURL,
+		"product_logo_url":      p.ProductLogoURL,
+		"contact_email_address": p.ContactEmailAddress,
+	}
+}
+
+// GetProductYAMLSchemaPath returns the path to the JSON Schema of PRODUCT.yaml in the data
+func GetProductYAMLSchemaPath() string {
+	return path.Join("schemas", ProductYAMLSchemaFileName)
+}
+
+// productYAMLSchema is the part of the JSON Schema used to describe problems
+type productYAMLSchema struct {
+	Properties map[string]json.RawMessage `json:"properties"`
+	Required   []string                   `json:"required"`
+}
+
+// compileProductYAMLSchema returns the JSON Schema of PRODUCT.yaml in fsys, compiled and as described for problems
+func compileProductYAMLSchema(fsys fs.FS) (*jsonschema.Schema, *productYAMLSchema, error) {
+	schemaBytes, err := fs.ReadFile(fsys, GetProductYAMLSchemaPath())
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to read PRODUCT.yaml schema, %v", err)
+	}
+	compiler := jsonschema.NewCompiler()
+	// formats like uri and email are only annotations from draft 2019-09 on, unless asserted
+	compiler.AssertFormat = true
+	if err := compiler.AddResource(ProductYAMLSchemaFileName, strings.NewReader(string(schemaBytes))); err != nil {
+		return nil, nil, fmt.Errorf("unable to load PRODUCT.yaml schema, %v", err)
+	}
+	schema, err := compiler.Compile(ProductYAMLSchemaFileName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to compile PRODUCT.yaml schema, %v", err)
+	}
+	described := &productYAMLSchema{}
+	if err := json.Unmarshal(schemaBytes, described); err != nil {
+		return nil, nil, fmt.Errorf("unable to parse PRODUCT.yaml schema, %v", err)
+	}
+	return schema, described, nil
+}
+
+// ValidateProductYAMLSchema checks that the JSON Schema of PRODUCT.yaml in fsys compiles
+func ValidateProductYAMLSchema(fsys fs.FS) error {
+	_, _, err := compileProductYAMLSchema(fsys)
+	return err
+}
+
+// ValidateProductYAML validates the contents of a PRODUCT.yaml against its JSON Schema in fsys,
+// returning every problem found such as unknown or missing fields and invalid values
+func ValidateProductYAML(fsys fs.FS, contents []byte) (problems []string, err error) {
+	schema, described, err := compileProductYAMLSchema(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	jsonContent, err := yaml.YAMLToJSON(contents)
+	if err != nil {
+		return nil, err
+	}
+	var instance interface{}
+	if err := json.Unmarshal(jsonContent, &instance); err != nil {
+		return nil, err
+	}
+	err = schema.Validate(instance)
+	if err == nil {
+		return nil, nil
+	}
+	validationErr := &jsonschema.ValidationError{}
+	if !errors.As(err, &validationErr) {
+		return nil, err
+	}
+	fields, _ := instance.(map[string]interface{})
+	for _, e := range validationErrorLeaves(validationErr) {
+		problems = append(problems, described.describe(e, fields)...)
+	}
+	return problems, nil
+}
+
+func validationErrorLeaves(err *jsonschema.ValidationError) (leaves []*jsonschema.ValidationError) {
+	if len(err.Causes) == 0 {
+		return []*jsonschema.ValidationError{err}
+	}
+	for _, c := range err.Causes {
+		leaves = append(leaves, validationErrorLeaves(c)...)
+	}
+	return leaves
+}
+
+// productYAMLFormatNames describe the formats of the fields in the schema
+var productYAMLFormatNames = map[string]string{
+	"uri":   "URL, like 'https://example.com'",
+	"email": "email address, like 'sales@example.com'",
+}
+
+// describe returns the problems of a validation error in terms of the fields in PRODUCT.yaml
+func (p productYAMLSchema) describe(err *jsonschema.ValidationError, fields map[string]interface{}) (problems []string) {
+	field := strings.TrimPrefix(err.InstanceLocation, "/")
+	keyword := path.Base(err.KeywordLocation)
+	switch {
+	case field == "" && keyword == "type":
+		return []string{"PRODUCT.yaml must contain fields, like 'name: my product'"}
+	case keyword == "additionalProperties":
+		unknown := []string{}
+		for name := range fields {
+			if _, found := p.Properties[name]; !found {
+				unknown = append(unknown, name)
+			}
+		}
+		sort.Strings(unknown)
+		for _, name := range unknown {
+			problem := fmt.Sprintf("unknown field '%v'", name)
+			if suggestion := p.closestProperty(name); suggestion != "" {
+				problem += fmt.Sprintf(", did you mean '%v'?", suggestion)
+			}
+			problems = append(problems, problem)
+		}
+		return problems
+	case keyword == "required":
+		for _, name := range p.Required {
+			if _, found := fields[name]; !found {
+				problems = append(problems, fmt.Sprintf("missing required field '%v'", name))
+			}
+		}
+		return problems
+	case keyword == "minLength", keyword == "type" && fields[field] == nil:
+		return []string{fmt.Sprintf("field '%v' is empty", field)}
+	case keyword == "enum":
+		return []string{fmt.Sprintf("field '%v' is not valid, the %v", field, err.Message)}
+	case keyword == "format":
+		property := struct {
+			Format string `json:"format"`
+		}{}
+		_ = json.Unmarshal(p.Properties[field], &property)
+		if name, found := productYAMLFormatNames[property.Format]; found {
+			return []string{fmt.Sprintf("field '%v' is not a valid %v", field, name)}
+		}
+	}
+	return []string{fmt.Sprintf("field '%v' is not valid, %v", field, err.Message)}
+}
+
+// closestProperty returns the property with a name close to name, in the case of a typo
+func (p productYAMLSchema) closestProperty(name string) (closest string) {
+	best := len(name)/3 + 1
+	for property := range p.Properties {
+		if d := levenshteinDistance(strings.ToLower(name), property); d <= best && (d < best || property < closest) {
+			best, closest = d, property
+		}
+	}
+	return closest
+}
+
+func levenshteinDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+	return previous[len(b)]
+}
+
+// getProductYAML returns the PRODUCT.yaml in the PR
+func (s *PRSuite) getProductYAML() (*ProductYAML, error) {
+	fileName := "PRODUCT.yaml"
+	file := s.GetFileByFileName(fileName)
+	if file == nil {
+		return nil, common.SafeError(fmt.Errorf("missing required file '%v'", fileName))
+	}
+	productYAML, err := ParseProductYAML([]byte(file.Contents))
+	if err != nil {
+		return nil, common.SafeError(fmt.Errorf("unable to read file '%v'", fileName))
+	}
+	return productYAML, nil
+}
+
+func (s *PRSuite) theProductYamlIsValidAgainstItsSchema() error {
+	file := s.GetFileByFileName("PRODUCT.yaml")
+	if file == nil {
+		return common.SafeError(fmt.Errorf("missing required file 'PRODUCT.yaml'"))
+	}
+	problems, err := ValidateProductYAML(s.DataFS, []byte(file.Contents))
+	if err != nil {
+		return common.SafeError(fmt.Errorf("unable to validate PRODUCT.yaml, %v", err))
+	}
+	if len(problems) > 0 {
+		return common.SafeError(fmt.Errorf("the PRODUCT.yaml has the following problem(s): \n    - %v", strings.Join(problems, "\n    - ")))
+	}
+	return nil
+}
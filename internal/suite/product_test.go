@@ -0,0 +1,228 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package suite
+
+import (
+	"encoding/json"
+	"html"
+	"io/fs"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"sigs.k8s.io/verify-conformance/internal/common"
+)
+
+const testProductYAML = `vendor: "cool"
+name: "coolkube"
+version: "v1.35"
+type: "distribution"
+description: "it's just all-round cool and probably the best k8s, idk"
+website_url: "https://coolkubernetes.com"
+documentation_url: "https://coolkubernetes.com/docs"
+contact_email_address: "sales@coolkubernetes.com"`
+
+func TestParseProductYAML(t *testing.T) {
+	productYAML, err := ParseProductYAML([]byte(testProductYAML + "\nsoup: minestrone"))
+	if err != nil {
+		t.Fatalf("error: unexpected error: %v", err)
+	}
+	want := &ProductYAML{
+		Vendor:              "cool",
+		Name:                "coolkube",
+		Version:             "v1.35",
+		Type:                "distribution",
+		Description:         "it's just all-round cool and probably the best k8s, idk",
+		WebsiteURL:          "https://coolkubernetes.com",
+		DocumentationURL:    "https://coolkubernetes.com/docs",
+		ContactEmailAddress: "sales@coolkubernetes.com",
+	}
+	if !reflect.DeepEqual(productYAML, want) {
+		t.Fatalf("error: unexpected PRODUCT.yaml; want = %+v; got = %+v", want, productYAML)
+	}
+	if got := productYAML.Fields()["website_url"]; got != want.WebsiteURL {
+		t.Fatalf("error: unexpected website_url field: %v", got)
+	}
+	for version, expected := range map[string]string{"4.16": "4.16", "1.0": "1.0", "4.10": "4.10", "2": "2"} {
+		productYAML, err := ParseProductYAML([]byte(strings.Replace(testProductYAML, `"v1.35"`, version, 1)))
+		if err != nil {
+			t.Fatalf("error: unexpected error parsing an unquoted version: %v", err)
+		}
+		if productYAML.Version != expected || productYAML.Name != want.Name {
+			t.Fatalf("error: unexpected PRODUCT.yaml for version %v; got = %+v", version, productYAML)
+		}
+	}
+	if _, err := ParseProductYAML([]byte(`%^(%^&(%&^%))`)); err == nil {
+		t.Fatalf("error: expected error parsing invalid yaml")
+	}
+}
+
+func TestProductYAMLSchemaMatchesProductYAML(t *testing.T) {
+	schemaContent, err := fs.ReadFile(common.GetDataFS(), GetProductYAMLSchemaPath())
+	if err != nil {
+		t.Fatalf("error: reading schema: %v", err)
+	}
+	schema := productYAMLSchema{}
+	if err := json.Unmarshal(schemaContent, &schema); err != nil {
+		t.Fatalf("error: parsing schema: %v", err)
+	}
+	for field := range (&ProductYAML{}).Fields() {
+		if _, found := schema.Properties[field]; !found {
+			t.Fatalf("error: field '%v' of ProductYAML is not in the schema", field)
+		}
+	}
+	if want, got := len((&ProductYAML{}).Fields()), len(schema.Properties); want != got {
+		t.Fatalf("error: expected %v properties in the schema; got = %v", want, got)
+	}
+}
+
+func TestValidateProductYAML(t *testing.T) {
+	type testCase struct {
+		Name             string
+		Contents         string
+		ExpectedProblems []string
+	}
+
+	for _, tc := range []testCase{
+		{
+			Name:     "valid",
+			Contents: testProductYAML,
+		},
+		{
+			Name:     "valid with optional fields",
+			Contents: testProductYAML + "\nrepo_url: \"https://github.com/cool/kube\"\nproduct_logo_url: \"https://coolkubernetes.com/logo.svg\"",
+		},
+		{
+			Name:     "valid with an unquoted version",
+			Contents: strings.Replace(testProductYAML, `"v1.35"`, "4.16", 1),
+		},
+		{
+			Name:     "invalid URL and email address",
+			Contents: strings.Replace(strings.Replace(testProductYAML, `"https://coolkubernetes.com/docs"`, `"coolkubernetes.com/docs"`, 1), `"sales@coolkubernetes.com"`, `"sales at coolkubernetes.com"`, 1),
+			ExpectedProblems: []string{
+				"field 'contact_email_address' is not a valid email address, like 'sales@example.com'",
+				"field 'documentation_url' is not a valid URL, like 'https://example.com'",
+			},
+		},
+		{
+			Name: "every problem at once",
+			Contents: `vendor: "cool"
+name: ""
+version: [1.35]
+type: "soup"
+description:
+webiste_url: "https://coolkubernetes.com"
+documentation_url: "https://coolkubernetes.com/docs"
+contact_email_address: "sales@coolkubernetes.com"
+flavour: "cool"`,
+			ExpectedProblems: []string{
+				"missing required field 'website_url'",
+				"unknown field 'flavour'",
+				"unknown field 'webiste_url', did you mean 'website_url'?",
+				"field 'description' is empty",
+				"field 'name' is empty",
+				`field 'type' is not valid, the value must be one of "distribution", "hosted platform", "installer"`,
+				"field 'version' is not valid, expected string or number, but got array",
+			},
+		},
+		{
+			Name:     "not a mapping",
+			Contents: `coolkube`,
+			ExpectedProblems: []string{
+				"PRODUCT.yaml must contain fields, like 'name: my product'",
+			},
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			problems, err := ValidateProductYAML(common.GetDataFS(), []byte(tc.Contents))
+			if err != nil {
+				t.Fatalf("error: unexpected error: %v", err)
+			}
+			sortedProblems := append([]string{}, problems...)
+			sort.Strings(sortedProblems)
+			expected := append([]string{}, tc.ExpectedProblems...)
+			sort.Strings(expected)
+			if len(expected) == 0 && len(sortedProblems) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(sortedProblems, expected) {
+				t.Fatalf("error: unexpected problems;\nwant = %v\ngot  = %v", strings.Join(expected, "\n       "), strings.Join(sortedProblems, "\n       "))
+			}
+		})
+	}
+}
+
+func TestTheProductYamlIsValidAgainstItsSchema(t *testing.T) {
+	type testCase struct {
+		Name                string
+		PullRequest         *PullRequest
+		ExpectedErrorString string
+	}
+
+	for _, tc := range []testCase{
+		{
+			Name: "valid",
+			PullRequest: &PullRequest{
+				SupportingFiles: []*PullRequestFile{{BaseName: "PRODUCT.yaml", Contents: testProductYAML}},
+			},
+		},
+		{
+			Name: "invalid",
+			PullRequest: &PullRequest{
+				SupportingFiles: []*PullRequestFile{{BaseName: "PRODUCT.yaml", Contents: strings.Replace(testProductYAML, "distribution", "soup", 1)}},
+			},
+			ExpectedErrorString: "the PRODUCT.yaml has the following problem(s): \n    - field 'type' is not valid",
+		},
+		{
+			Name: "bad yaml",
+			PullRequest: &PullRequest{
+				SupportingFiles: []*PullRequestFile{{BaseName: "PRODUCT.yaml", Contents: `%^(%^&(%&^%))`}},
+			},
+			ExpectedErrorString: "unable to validate PRODUCT.yaml",
+		},
+		{
+			Name:                "file not found",
+			PullRequest:         &PullRequest{},
+			ExpectedErrorString: "missing required file 'PRODUCT.yaml'",
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			err := NewPRSuite(tc.PullRequest).theProductYamlIsValidAgainstItsSchema()
+			if tc.ExpectedErrorString == "" && err != nil {
+				t.Fatalf("error: unexpected error: %v", err)
+			}
+			if tc.ExpectedErrorString != "" && (err == nil || !strings.Contains(html.UnescapeString(err.Error()), tc.ExpectedErrorString)) {
+				t.Fatalf("error: expected error containing '%v'; got = %v", tc.ExpectedErrorString, err)
+			}
+		})
+	}
+}
+
+func TestTheProductYamlIsValidAgainstItsSchemaInDataFS(t *testing.T) {
+	prSuite := NewPRSuite(&PullRequest{
+		SupportingFiles: []*PullRequestFile{{BaseName: "PRODUCT.yaml", Contents: testProductYAML}},
+	})
+	prSuite.DataFS = fstest.MapFS{
+		GetProductYAMLSchemaPath(): {Data: []byte(`{"type": "object", "required": ["soup"]}`)},
+	}
+	err := prSuite.theProductYamlIsValidAgainstItsSchema()
+	if err == nil || !strings.Contains(html.UnescapeString(err.Error()), "missing required field 'soup'") {
+		t.Fatalf("error: expected the schema in the data of the suite to be used; got = %v", err)
+	}
+}
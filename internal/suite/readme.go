@@ -0,0 +1,173 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package suite
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"sigs.k8s.io/verify-conformance/internal/common"
+)
+
+var (
+	markdownATXHeadingRegexp    = regexp.MustCompile(`^ {0,3}#{1,6}(?:\s+(.*?))?\s*#*\s*$`)
+	markdownSetextHeadingRegexp = regexp.MustCompile(`^ {0,3}(=+|-+)\s*$`)
+	markdownCodeFenceRegexp     = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})\\s*([^`\\s]*)")
+
+	// shellLanguages are the info strings of fenced code blocks holding commands,
+	// where no info string is taken as commands too
+	shellLanguages = []string{"", "sh", "bash", "shell", "console", "zsh", "powershell"}
+	// conformanceRunCommands are the tools used to run the conformance tests
+	conformanceRunCommands = []string{"sonobuoy", "hydrophone", "e2e.test", "ginkgo"}
+)
+
+// Markdown is the structure of a markdown document, for checking a README.md
+type Markdown struct {
+	Headings   []string
+	CodeBlocks []MarkdownCodeBlock
+}
+
+// MarkdownCodeBlock is a fenced code block
+type MarkdownCodeBlock struct {
+	// Language is the info string of the fence, e.g. sh
+	Language string
+	Contents string
+	// Line is where the fence opens, starting from 1
+	Line int
+}
+
+// IsShell returns whether the code block holds commands to run
+func (b MarkdownCodeBlock) IsShell() bool {
+	for _, l := range shellLanguages {
+		if strings.EqualFold(b.Language, l) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseMarkdown returns the headings and fenced code blocks of a markdown document,
+// returning an error when a code block is not closed
+func ParseMarkdown(contents string) (*Markdown, error) {
+	md := &Markdown{}
+	var block *MarkdownCodeBlock
+	var fence string
+	var blockLines []string
+	previousLine := ""
+	for i, line := range strings.Split(contents, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if block != nil {
+			trimmed := strings.TrimSpace(line)
+			if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" {
+				block.Contents = strings.Join(blockLines, "\n")
+				md.CodeBlocks = append(md.CodeBlocks, *block)
+				block, blockLines, previousLine = nil, nil, ""
+				continue
+			}
+			blockLines = append(blockLines, line)
+			continue
+		}
+		if m := markdownCodeFenceRegexp.FindStringSubmatch(line); m != nil {
+			fence = m[1]
+			block = &MarkdownCodeBlock{Language: m[2], Line: i + 1}
+			previousLine = ""
+			continue
+		}
+		if m := markdownATXHeadingRegexp.FindStringSubmatch(line); m != nil {
+			md.Headings = append(md.Headings, m[1])
+			previousLine = ""
+			continue
+		}
+		if markdownSetextHeadingRegexp.MatchString(line) && strings.TrimSpace(previousLine) != "" {
+			md.Headings = append(md.Headings, strings.TrimSpace(previousLine))
+			previousLine = ""
+			continue
+		}
+		previousLine = line
+	}
+	if block != nil {
+		return nil, fmt.Errorf("the code block opened on line %v is not closed", block.Line)
+	}
+	return md, nil
+}
+
+// getProductNames returns the names the product may be referred to by,
+// from the folder structure and the name field in PRODUCT.yaml
+func (s *PRSuite) getProductNames() (names []string) {
+	if s.ProductName != "" {
+		names = append(names, s.ProductName)
+	}
+	if productYAML, err := s.getProductYAML(); err == nil && productYAML.Name != "" {
+		names = append(names, productYAML.Name)
+	}
+	return names
+}
+
+func (s *PRSuite) theReadmeContainsInstructionsToReproduceTheResults() error {
+	file := s.GetFileByFileName("README.md")
+	if file == nil {
+		return common.SafeError(fmt.Errorf("unable to find file README.md"))
+	}
+	md, err := ParseMarkdown(file.Contents)
+	if err != nil {
+		return common.SafeError(fmt.Errorf("failed to parse README.md, %v", err))
+	}
+
+	missingSections := []string{}
+	if len(md.Headings) == 0 {
+		missingSections = append(missingSections, "a heading")
+	}
+	hasClusterBlock, hasConformanceBlock := false, false
+	for _, b := range md.CodeBlocks {
+		if !b.IsShell() {
+			continue
+		}
+		runsConformance := false
+		for _, c := range conformanceRunCommands {
+			if strings.Contains(b.Contents, c) {
+				runsConformance = true
+			}
+		}
+		if runsConformance {
+			hasConformanceBlock = true
+		} else {
+			hasClusterBlock = true
+		}
+	}
+	if !hasClusterBlock {
+		missingSections = append(missingSections, "a fenced shell code block with the commands to create the cluster")
+	}
+	if !hasConformanceBlock {
+		missingSections = append(missingSections, fmt.Sprintf("a fenced shell code block with the commands to run the conformance tests (using one of: %v)", strings.Join(conformanceRunCommands, ", ")))
+	}
+	productNames := s.getProductNames()
+	mentionsProduct := false
+	for _, name := range productNames {
+		if strings.Contains(strings.ToLower(file.Contents), strings.ToLower(name)) {
+			mentionsProduct = true
+		}
+	}
+	if len(productNames) > 0 && !mentionsProduct {
+		missingSections = append(missingSections, fmt.Sprintf("a mention of the product name (%v)", strings.Join(productNames, " or ")))
+	}
+
+	if len(missingSections) > 0 {
+		return common.SafeError(fmt.Errorf("the README.md is missing the following: \n    - %v", strings.Join(missingSections, "\n    - ")))
+	}
+	return nil
+}
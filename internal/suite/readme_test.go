@@ -0,0 +1,169 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package suite
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseMarkdown(t *testing.T) {
+	type testCase struct {
+		Name                string
+		Contents            string
+		Expected            *Markdown
+		ExpectedErrorString string
+	}
+
+	for _, tc := range []testCase{
+		{
+			Name:     "readme",
+			Contents: testReadmeCoolkubeV135README,
+			Expected: &Markdown{
+				Headings: []string{"coolkube", "Creating a cluster", "Running the conformance tests"},
+				CodeBlocks: []MarkdownCodeBlock{
+					{Language: "sh", Contents: "coolkube create cluster --version v1.35.0", Line: 6},
+					{Language: "sh", Contents: "sonobuoy run --mode=certified-conformance --wait\nsonobuoy retrieve", Line: 12},
+				},
+			},
+		},
+		{
+			Name: "setext headings and tildes",
+			Contents: `coolkube
+========
+
+Install
+-------
+
+~~~
+# not a heading
+coolkube up
+~~~`,
+			Expected: &Markdown{
+				Headings: []string{"coolkube", "Install"},
+				CodeBlocks: []MarkdownCodeBlock{
+					{Contents: "# not a heading\ncoolkube up", Line: 7},
+				},
+			},
+		},
+		{
+			Name:                "unclosed code block",
+			Contents:            "# coolkube\n\n```bash\ncoolkube up\n",
+			ExpectedErrorString: "the code block opened on line 3 is not closed",
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			md, err := ParseMarkdown(tc.Contents)
+			if tc.ExpectedErrorString != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.ExpectedErrorString) {
+					t.Fatalf("error: expected error containing '%v'; got = %v", tc.ExpectedErrorString, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error: unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(md, tc.Expected) {
+				t.Fatalf("error: unexpected markdown; want = %+v; got = %+v", tc.Expected, md)
+			}
+		})
+	}
+}
+
+func TestTheReadmeContainsInstructionsToReproduceTheResults(t *testing.T) {
+	type testCase struct {
+		Name                string
+		Readme              string
+		ProductYAML         string
+		ExpectedErrorString string
+	}
+
+	for _, tc := range []testCase{
+		{
+			Name:   "complete readme",
+			Readme: testReadmeCoolkubeV135README,
+		},
+		{
+			Name: "product name from PRODUCT.yaml",
+			Readme: `# The Cool Engine
+
+~~~console
+$ cool-engine init
+~~~
+
+~~~console
+$ hydrophone --conformance
+~~~`,
+			ProductYAML: `name: "Cool Engine"`,
+		},
+		{
+			Name:   "instructions not in code blocks",
+			Readme: "# coolkube\n\n1. create a coolkube cluster\n2. sonobuoy run --wait",
+			ExpectedErrorString: "the README.md is missing the following: \n" +
+				"    - a fenced shell code block with the commands to create the cluster\n" +
+				"    - a fenced shell code block with the commands to run the conformance tests",
+		},
+		{
+			Name:                "no heading",
+			Readme:              "coolkube\n\n~~~sh\ncoolkube up\n~~~\n\n~~~sh\nsonobuoy run\n~~~",
+			ExpectedErrorString: "    - a heading",
+		},
+		{
+			Name:                "code blocks which aren't commands",
+			Readme:              "# coolkube\n\n~~~yaml\nsonobuoy: true\n~~~\n\n~~~sh\ncoolkube up\n~~~",
+			ExpectedErrorString: "    - a fenced shell code block with the commands to run the conformance tests",
+		},
+		{
+			Name:                "product not mentioned",
+			Readme:              "# Our product\n\n~~~sh\nmake cluster\n~~~\n\n~~~sh\nsonobuoy run\n~~~",
+			ProductYAML:         `name: "CoolKube Enterprise"`,
+			ExpectedErrorString: "    - a mention of the product name (coolkube or CoolKube Enterprise)",
+		},
+		{
+			Name:                "unclosed code block",
+			Readme:              "# coolkube\n\n~~~sh\ncoolkube up",
+			ExpectedErrorString: "failed to parse README.md, the code block opened on line 3 is not closed",
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			files := []*PullRequestFile{
+				{
+					Name:     "v1.35/coolkube/README.md",
+					BaseName: "README.md",
+					Contents: tc.Readme,
+				},
+			}
+			if tc.ProductYAML != "" {
+				files = append(files, &PullRequestFile{
+					Name:     "v1.35/coolkube/PRODUCT.yaml",
+					BaseName: "PRODUCT.yaml",
+					Contents: tc.ProductYAML,
+				})
+			}
+			prSuite := NewPRSuite(&PullRequest{SupportingFiles: files})
+			prSuite.ProductName = "coolkube"
+			err := prSuite.theReadmeContainsInstructionsToReproduceTheResults()
+			if tc.ExpectedErrorString == "" && err != nil {
+				t.Fatalf("error: unexpected error: %v", err)
+			}
+			if tc.ExpectedErrorString != "" && (err == nil || !strings.Contains(err.Error(), tc.ExpectedErrorString)) {
+				t.Fatalf("error: expected error containing '%v'; got = %v", tc.ExpectedErrorString, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package suite
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// ReleaseVersionPattern matches a Kubernetes release version, like v1.35 or v1.35.2
+	ReleaseVersionPattern = `v[0-9]+\.[0-9]+(?:\.[0-9]+)?`
+	// ReleaseVersionPlaceholder is replaced with ReleaseVersionPattern in the patterns given to steps,
+	// so that the feature file doesn't need its own pattern for release versions
+	ReleaseVersionPlaceholder = "{release}"
+)
+
+var (
+	releaseVersionRegexp = regexp.MustCompile(`^v([0-9]+)\.([0-9]+)(?:\.([0-9]+))?$`)
+	// submissionFolderRegexp matches the release version and product name in the path of a file in a submission
+	submissionFolderRegexp = regexp.MustCompile(`(?:^|/)(` + ReleaseVersionPattern + `)/([^/]+)/`)
+	// titleRegexp matches the prefix, release version and product name in the title of a PR,
+	// like "Conformance results for v1.35/coolkube"
+	titleRegexp = regexp.MustCompile(`(.*) (` + ReleaseVersionPattern + `)[ /](.*)`)
+)
+
+// ReleaseVersion is a Kubernetes release version, with an optional patch
+type ReleaseVersion struct {
+	Major    int
+	Minor    int
+	Patch    int
+	HasPatch bool
+}
+
+// ParseReleaseVersion parses a release version like v1.35 or v1.35.2
+func ParseReleaseVersion(version string) (*ReleaseVersion, error) {
+	m := releaseVersionRegexp.FindStringSubmatch(version)
+	if m == nil {
+		return nil, fmt.Errorf("'%v' is not a release version like v1.35 or v1.35.2", version)
+	}
+	v := &ReleaseVersion{}
+	v.Major, _ = strconv.Atoi(m[1])
+	v.Minor, _ = strconv.Atoi(m[2])
+	if m[3] != "" {
+		v.Patch, _ = strconv.Atoi(m[3])
+		v.HasPatch = true
+	}
+	return v, nil
+}
+
+// MinorVersion returns the release version without the patch, like v1.35, which is how
+// submissions are foldered and the conformance metadata is looked up
+func (v *ReleaseVersion) MinorVersion() string {
+	return fmt.Sprintf("v%v.%v", v.Major, v.Minor)
+}
+
+// String returns the release version with the patch, when there is one
+func (v *ReleaseVersion) String() string {
+	if !v.HasPatch {
+		return v.MinorVersion()
+	}
+	return fmt.Sprintf("v%v.%v.%v", v.Major, v.Minor, v.Patch)
+}
+
+// expandReleaseVersionPattern replaces ReleaseVersionPlaceholder in a pattern from the feature file
+func expandReleaseVersionPattern(pattern string) string {
+	return strings.ReplaceAll(pattern, ReleaseVersionPlaceholder, ReleaseVersionPattern)
+}
+
+// titleParts returns the prefix, release version and product name in the title of the PR,
+// like "Conformance results for", v1.35 and "CoolKube" in "Conformance results for v1.35/CoolKube"
+func (s *PRSuite) titleParts() (prefix string, releaseVersion *ReleaseVersion, productName string) {
+	m := titleRegexp.FindStringSubmatch(string(s.PR.Title))
+	if m == nil {
+		return "", nil, ""
+	}
+	releaseVersion, _ = ParseReleaseVersion(m[2])
+	return m[1], releaseVersion, strings.TrimSpace(m[3])
+}
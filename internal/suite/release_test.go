@@ -0,0 +1,116 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package suite
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	githubql "github.com/shurcooL/githubv4"
+)
+
+func TestParseReleaseVersion(t *testing.T) {
+	type testCase struct {
+		Version               string
+		Expected              *ReleaseVersion
+		ExpectedMinorVersion  string
+		ExpectedErrorContains string
+	}
+
+	for _, tc := range []testCase{
+		{Version: "v1.35", Expected: &ReleaseVersion{Major: 1, Minor: 35}, ExpectedMinorVersion: "v1.35"},
+		{Version: "v1.35.2", Expected: &ReleaseVersion{Major: 1, Minor: 35, Patch: 2, HasPatch: true}, ExpectedMinorVersion: "v1.35"},
+		{Version: "v1.9", Expected: &ReleaseVersion{Major: 1, Minor: 9}, ExpectedMinorVersion: "v1.9"},
+		{Version: "v2.0.0", Expected: &ReleaseVersion{Major: 2, Minor: 0, HasPatch: true}, ExpectedMinorVersion: "v2.0"},
+		{Version: "v1x35", ExpectedErrorContains: "is not a release version"},
+		{Version: "1.35", ExpectedErrorContains: "is not a release version"},
+		{Version: "v1.35.2+k3s1", ExpectedErrorContains: "is not a release version"},
+	} {
+		t.Run(tc.Version, func(t *testing.T) {
+			v, err := ParseReleaseVersion(tc.Version)
+			if tc.ExpectedErrorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.ExpectedErrorContains) {
+					t.Fatalf("error: expected error containing '%v'; got = %v", tc.ExpectedErrorContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error: unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(v, tc.Expected) {
+				t.Fatalf("error: unexpected release version; want = %+v; got = %+v", tc.Expected, v)
+			}
+			if v.MinorVersion() != tc.ExpectedMinorVersion {
+				t.Fatalf("error: unexpected minor version; want = %v; got = %v", tc.ExpectedMinorVersion, v.MinorVersion())
+			}
+			if v.String() != tc.Version {
+				t.Fatalf("error: unexpected string; want = %v; got = %v", tc.Version, v.String())
+			}
+		})
+	}
+}
+
+func TestTitleParts(t *testing.T) {
+	type testCase struct {
+		Title                  string
+		ExpectedPrefix         string
+		ExpectedReleaseVersion string
+		ExpectedProductName    string
+	}
+
+	for _, tc := range []testCase{
+		{Title: "Conformance results for v1.35/coolkube", ExpectedPrefix: "Conformance results for", ExpectedReleaseVersion: "v1.35", ExpectedProductName: "coolkube"},
+		{Title: "Conformance results for v1.35.2 Cool Kube", ExpectedPrefix: "Conformance results for", ExpectedReleaseVersion: "v1.35.2", ExpectedProductName: "Cool Kube"},
+		{Title: "Conformance results for v2.1/coolkube", ExpectedPrefix: "Conformance results for", ExpectedReleaseVersion: "v2.1", ExpectedProductName: "coolkube"},
+		{Title: "Conformance results for v1x35/coolkube"},
+	} {
+		t.Run(tc.Title, func(t *testing.T) {
+			prSuite := NewPRSuite(&PullRequest{})
+			prSuite.PR.Title = githubql.String(tc.Title)
+			prefix, releaseVersion, productName := prSuite.titleParts()
+			gotReleaseVersion := ""
+			if releaseVersion != nil {
+				gotReleaseVersion = releaseVersion.String()
+			}
+			if prefix != tc.ExpectedPrefix || gotReleaseVersion != tc.ExpectedReleaseVersion || productName != tc.ExpectedProductName {
+				t.Fatalf("error: unexpected title parts; want = (%v, %v, %v); got = (%v, %v, %v)", tc.ExpectedPrefix, tc.ExpectedReleaseVersion, tc.ExpectedProductName, prefix, gotReleaseVersion, productName)
+			}
+		})
+	}
+}
+
+func TestSetSubmissionMetadatafromFolderStructureReleaseVersions(t *testing.T) {
+	for _, tc := range []struct {
+		File                   string
+		ExpectedReleaseVersion string
+		ExpectedProductName    string
+	}{
+		{File: "v1.35/coolkube/README.md", ExpectedReleaseVersion: "v1.35", ExpectedProductName: "coolkube"},
+		{File: "v1.35.2/coolkube/README.md", ExpectedReleaseVersion: "v1.35", ExpectedProductName: "coolkube"},
+		{File: "v2.0/coolkube/docs/README.md", ExpectedReleaseVersion: "v2.0", ExpectedProductName: "coolkube"},
+		{File: "v1x35/coolkube/README.md"},
+	} {
+		t.Run(tc.File, func(t *testing.T) {
+			prSuite := NewPRSuite(&PullRequest{SupportingFiles: []*PullRequestFile{{Name: tc.File}}})
+			prSuite.SetSubmissionMetadatafromFolderStructure()
+			if prSuite.KubernetesReleaseVersion != tc.ExpectedReleaseVersion || prSuite.ProductName != tc.ExpectedProductName {
+				t.Fatalf("error: unexpected metadata; want = (%v, %v); got = (%v, %v)", tc.ExpectedReleaseVersion, tc.ExpectedProductName, prSuite.KubernetesReleaseVersion, prSuite.ProductName)
+			}
+		})
+	}
+}
@@ -0,0 +1,373 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package suite
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/verify-conformance/internal/types"
+)
+
+const (
+	ScenarioStatusPassed  = "passed"
+	ScenarioStatusFailed  = "failed"
+	ScenarioStatusSkipped = "skipped"
+
+	reportToolName = "verify-conformance"
+	reportToolURI  = "https://github.com/kubernetes-sigs/verify-conformance"
+	sarifVersion   = "2.1.0"
+	sarifSchema    = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+var ruleIDInvalidChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// ScenarioResult is the outcome of a scenario, including all rows of its examples
+type ScenarioResult struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Status      string   `json:"status"`
+	Hints       []string `json:"hints,omitempty"`
+}
+
+// Report is the outcome of checking a submission
+type Report struct {
+	Title                    string           `json:"title"`
+	KubernetesReleaseVersion string           `json:"kubernetesReleaseVersion"`
+	ProductName              string           `json:"productName"`
+	State                    string           `json:"state"`
+	Labels                   []string         `json:"labels"`
+	Comment                  string           `json:"comment"`
+	Scenarios                []ScenarioResult `json:"scenarios"`
+	MissingTests             []string         `json:"missingTests,omitempty"`
+	FailedTests              []string         `json:"failedTests,omitempty"`
+	Files                    []string         `json:"files,omitempty"`
+	Annotations              []Annotation     `json:"annotations,omitempty"`
+}
+
+// NewReport returns a Report about the submission without scenarios,
+// for when the suite can't be run
+func (s *PRSuite) NewReport(comment string, labels []string, state string) *Report {
+	r := &Report{
+		Title:                    string(s.PR.Title),
+		KubernetesReleaseVersion: s.KubernetesReleaseVersion,
+		ProductName:              s.ProductName,
+		State:                    state,
+		Labels:                   labels,
+		Comment:                  comment,
+	}
+	for _, f := range s.PR.SupportingFiles {
+		r.Files = append(r.Files, f.Name)
+	}
+	return r
+}
+
+// GetReport returns the Report for the suite once it has run.
+// It is used in place of GetLabelsAndCommentsFromSuiteResultsBuffer, which it calls.
+func (s *PRSuite) GetReport() (*Report, error) {
+	comment, labels, state, err := s.GetLabelsAndCommentsFromSuiteResultsBuffer()
+	if err != nil {
+		return nil, err
+	}
+	r := s.NewReport(comment, labels, state)
+
+	cukeFeatures := []types.CukeFeatureJSON{}
+	if err := json.Unmarshal(s.buffer.Bytes(), &cukeFeatures); err != nil {
+		return nil, err
+	}
+	r.Scenarios = scenarioResultsFromCukeFeatures(cukeFeatures)
+
+	r.MissingTests, r.FailedTests = s.junitTestResults()
+	r.Annotations = s.getAnnotations(r)
+	return r, nil
+}
+
+// junitTestResults returns the required tests which are missing or failed and the tests which failed,
+// when there is a junit_01.xml
+func (s *PRSuite) junitTestResults() (missingTests []string, failedTests []string) {
+	if s.GetFileByFileName("junit_01.xml") == nil {
+		return nil, nil
+	}
+	if tests, err := s.GetMissingJunitTestsFromPRSuite(); err == nil {
+		sort.Strings(tests)
+		missingTests = tests
+	}
+	if tests, err := s.GetJunitFailedConformanceTests(); err == nil {
+		failedTests = tests
+	}
+	return missingTests, failedTests
+}
+
+// GetJunitFailedConformanceTests returns the names of the conformance tests which failed in the junit_01.xml
+func (s *PRSuite) GetJunitFailedConformanceTests() (tests []string, err error) {
+	collectedTests, err := s.getJunitSubmittedConformanceTests()
+	if err != nil {
+		return []string{}, err
+	}
+	for _, t := range collectedTests {
+		if t.Failure == nil && t.ErrorMessage == nil {
+			continue
+		}
+		tests = append(tests, strings.TrimPrefix(t.Name, "[It] "))
+	}
+	sort.Strings(tests)
+	return tests, nil
+}
+
+// scenarioResultsFromCukeFeatures collects the results of each uniquely named scenario,
+// as each row of the examples of a scenario is reported on its own
+func scenarioResultsFromCukeFeatures(cukeFeatures []types.CukeFeatureJSON) (results []ScenarioResult) {
+	indexes := map[string]int{}
+	for _, c := range cukeFeatures {
+		for _, e := range c.Elements {
+			i, found := indexes[e.Name]
+			if !found {
+				i = len(results)
+				indexes[e.Name] = i
+				results = append(results, ScenarioResult{
+					Name:        e.Name,
+					Description: strings.TrimSpace(e.Description),
+					Status:      ScenarioStatusPassed,
+				})
+			}
+			result := &results[i]
+			for _, step := range e.Steps {
+				switch step.Result.Status {
+				case ScenarioStatusPassed:
+				case ScenarioStatusFailed:
+					result.Status = ScenarioStatusFailed
+					// hints are HTML escaped for use in comments
+					hint := html.UnescapeString(step.Result.Error)
+					if !containsString(result.Hints, hint) {
+						result.Hints = append(result.Hints, hint)
+					}
+				default:
+					if result.Status == ScenarioStatusPassed {
+						result.Status = ScenarioStatusSkipped
+					}
+				}
+			}
+		}
+	}
+	return results
+}
+
+func containsString(list []string, s string) bool {
+	for _, l := range list {
+		if l == s {
+			return true
+		}
+	}
+	return false
+}
+
+// String formats the report as text
+func (r *Report) String() string {
+	return fmt.Sprintf("Title: %v\nRelease Version: %v\nState: %v\nLabels: %v\n\n%v",
+		r.Title, r.KubernetesReleaseVersion, r.State, strings.Join(r.Labels, ", "), r.Comment)
+}
+
+// JSON serializes the report as JSON
+func (r *Report) JSON() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name       string          `xml:"name,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Skipped    int             `xml:"skipped,attr"`
+	Properties []junitProperty `xml:"properties>property,omitempty"`
+	TestCases  []junitTestCase `xml:"testcase"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *struct{}     `xml:"skipped,omitempty"`
+}
+
+type junitFailure struct {
+	Message  string `xml:"message,attr"`
+	Contents string `xml:",chardata"`
+}
+
+// JUnit serializes the report as JUnit XML, with a test case for each scenario
+func (r *Report) JUnit() ([]byte, error) {
+	ts := junitTestSuite{
+		Name: reportToolName,
+		Properties: []junitProperty{
+			{Name: "title", Value: r.Title},
+			{Name: "kubernetesReleaseVersion", Value: r.KubernetesReleaseVersion},
+			{Name: "productName", Value: r.ProductName},
+			{Name: "state", Value: r.State},
+			{Name: "labels", Value: strings.Join(r.Labels, ",")},
+		},
+	}
+	for _, sc := range r.Scenarios {
+		tc := junitTestCase{Name: sc.Name, ClassName: reportToolName}
+		switch sc.Status {
+		case ScenarioStatusFailed:
+			tc.Failure = &junitFailure{Message: sc.Description, Contents: strings.Join(sc.Hints, "\n")}
+			ts.Failures++
+		case ScenarioStatusSkipped:
+			tc.Skipped = &struct{}{}
+			ts.Skipped++
+		}
+		ts.TestCases = append(ts.TestCases, tc)
+		ts.Tests++
+	}
+	out, err := xml.MarshalIndent(junitTestSuites{
+		Tests:    ts.Tests,
+		Failures: ts.Failures,
+		Skipped:  ts.Skipped,
+		Suites:   []junitTestSuite{ts},
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), out...), nil
+}
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	Name             string       `json:"name"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+	FullDescription  sarifMessage `json:"fullDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// ruleID returns an identifier for a scenario, e.g. "all-tests-pass"
+func ruleID(name string) string {
+	return strings.Trim(ruleIDInvalidChars.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
+
+// SARIF serializes the report as SARIF, with a rule for each scenario and a result for each failed one.
+// Results are located at the submission file they mention, where there is one.
+func (r *Report) SARIF() ([]byte, error) {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           reportToolName,
+			InformationURI: reportToolURI,
+			Rules:          []sarifRule{},
+		}},
+		Results: []sarifResult{},
+	}
+	for _, sc := range r.Scenarios {
+		id := ruleID(sc.Name)
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+			ID:               id,
+			Name:             sc.Name,
+			ShortDescription: sarifMessage{Text: sc.Name},
+			FullDescription:  sarifMessage{Text: sc.Description},
+		})
+		if sc.Status != ScenarioStatusFailed {
+			continue
+		}
+		result := sarifResult{
+			RuleID:  id,
+			Level:   "error",
+			Message: sarifMessage{Text: strings.Join(append([]string{sc.Description}, sc.Hints...), "\n")},
+		}
+		if file := r.fileMentionedIn(append([]string{sc.Name}, sc.Hints...)...); file != "" {
+			result.Locations = []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: file},
+			}}}
+		}
+		run.Results = append(run.Results, result)
+	}
+	return json.MarshalIndent(sarifLog{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs:    []sarifRun{run},
+	}, "", "  ")
+}
+
+// fileMentionedIn returns the first submission file whose name is mentioned in any of texts
+func (r *Report) fileMentionedIn(texts ...string) string {
+	for _, f := range r.Files {
+		for _, t := range texts {
+			if strings.Contains(t, path.Base(f)) {
+				return f
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package suite
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"reflect"
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/verify-conformance/internal/types"
+)
+
+const testCukeFeaturesJSON = `[
+  {
+    "name": "verify conformance",
+    "elements": [
+      {
+        "name": "all required files are present",
+        "description": "  there seems to be some required files missing  ",
+        "steps": [
+          {"result": {"status": "passed"}},
+          {"result": {"status": "failed", "error_message": "missing file &#39;junit_01.xml&#39;"}}
+        ]
+      },
+      {
+        "name": "all required files are present",
+        "description": "  there seems to be some required files missing  ",
+        "steps": [
+          {"result": {"status": "failed", "error_message": "missing file &#39;junit_01.xml&#39;"}}
+        ]
+      },
+      {
+        "name": "the title is not empty",
+        "steps": [
+          {"result": {"status": "passed"}}
+        ]
+      },
+      {
+        "name": "the commit count",
+        "steps": [
+          {"result": {"status": "skipped"}}
+        ]
+      }
+    ]
+  }
+]`
+
+func newTestReport(t *testing.T) *Report {
+	cukeFeatures := []types.CukeFeatureJSON{}
+	if err := json.Unmarshal([]byte(testCukeFeaturesJSON), &cukeFeatures); err != nil {
+		t.Fatalf("error: parsing cucumber json: %v", err)
+	}
+	return &Report{
+		Title:                    "Conformance results for v1.35/coolkube",
+		KubernetesReleaseVersion: "v1.35",
+		ProductName:              "coolkube",
+		State:                    "failure",
+		Labels:                   []string{"conformance-product-submission", "required-tests-missing"},
+		Scenarios:                scenarioResultsFromCukeFeatures(cukeFeatures),
+		Files:                    []string{"v1.35/coolkube/PRODUCT.yaml", "v1.35/coolkube/junit_01.xml"},
+	}
+}
+
+func TestScenarioResultsFromCukeFeatures(t *testing.T) {
+	want := []ScenarioResult{
+		{
+			Name:        "all required files are present",
+			Description: "there seems to be some required files missing",
+			Status:      ScenarioStatusFailed,
+			Hints:       []string{"missing file 'junit_01.xml'"},
+		},
+		{
+			Name:   "the title is not empty",
+			Status: ScenarioStatusPassed,
+		},
+		{
+			Name:   "the commit count",
+			Status: ScenarioStatusSkipped,
+		},
+	}
+	if got := newTestReport(t).Scenarios; !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected scenarios: want = %+v; got = %+v", want, got)
+	}
+}
+
+func TestReportJSON(t *testing.T) {
+	r := newTestReport(t)
+	out, err := r.JSON()
+	if err != nil {
+		t.Fatalf("error: unexpected error: %v", err)
+	}
+	got := &Report{}
+	if err := json.Unmarshal(out, got); err != nil {
+		t.Fatalf("error: parsing report: %v", err)
+	}
+	if !reflect.DeepEqual(got, r) {
+		t.Fatalf("unexpected report: want = %+v; got = %+v", r, got)
+	}
+}
+
+func TestReportJUnit(t *testing.T) {
+	out, err := newTestReport(t).JUnit()
+	if err != nil {
+		t.Fatalf("error: unexpected error: %v", err)
+	}
+	got := junitTestSuites{}
+	if err := xml.Unmarshal(out, &got); err != nil {
+		t.Fatalf("error: parsing junit: %v", err)
+	}
+	if got.Tests != 3 || got.Failures != 1 || got.Skipped != 1 {
+		t.Fatalf("unexpected counts: tests = %v; failures = %v; skipped = %v", got.Tests, got.Failures, got.Skipped)
+	}
+	if len(got.Suites) != 1 || len(got.Suites[0].TestCases) != 3 {
+		t.Fatalf("unexpected test suites: %+v", got.Suites)
+	}
+	failure := got.Suites[0].TestCases[0].Failure
+	if failure == nil || failure.Contents != "missing file 'junit_01.xml'" {
+		t.Fatalf("unexpected failure: %+v", failure)
+	}
+	if !strings.Contains(string(out), `<property name="state" value="failure"></property>`) {
+		t.Fatalf("unexpected properties in: %v", string(out))
+	}
+}
+
+func TestReportSARIF(t *testing.T) {
+	out, err := newTestReport(t).SARIF()
+	if err != nil {
+		t.Fatalf("error: unexpected error: %v", err)
+	}
+	got := sarifLog{}
+	if err := json.Unmarshal(out, &got); err != nil {
+		t.Fatalf("error: parsing sarif: %v", err)
+	}
+	if got.Version != sarifVersion || len(got.Runs) != 1 {
+		t.Fatalf("unexpected sarif log: %+v", got)
+	}
+	run := got.Runs[0]
+	if len(run.Tool.Driver.Rules) != 3 {
+		t.Fatalf("unexpected rules: %+v", run.Tool.Driver.Rules)
+	}
+	want := []sarifResult{
+		{
+			RuleID:  "all-required-files-are-present",
+			Level:   "error",
+			Message: sarifMessage{Text: "there seems to be some required files missing\nmissing file 'junit_01.xml'"},
+			Locations: []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: "v1.35/coolkube/junit_01.xml"},
+			}}},
+		},
+	}
+	if !reflect.DeepEqual(run.Results, want) {
+		t.Fatalf("unexpected results: want = %+v; got = %+v", want, run.Results)
+	}
+}
+
+func TestRuleID(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		want string
+	}{
+		{name: "all tests pass", want: "all-tests-pass"},
+		{name: "submission has files in structure of releaseversion/productname/", want: "submission-has-files-in-structure-of-releaseversion-productname"},
+		{name: "  PRODUCT.yaml has a type  ", want: "product-yaml-has-a-type"},
+	} {
+		if got := ruleID(tc.name); got != tc.want {
+			t.Fatalf("unexpected rule id for '%v': want = %v; got = %v", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestGetJunitFailedConformanceTests(t *testing.T) {
+	prSuite := NewPRSuite(&PullRequest{
+		SupportingFiles: []*PullRequestFile{
+			{
+				Name:     "v1.35/coolkube/junit_01.xml",
+				BaseName: "junit_01.xml",
+				Contents: testGetJunitSubmittedConformanceTestsCoolkubeV133Junit_01WithOneTestFailedxml,
+			},
+		},
+	})
+	tests, err := prSuite.GetJunitFailedConformanceTests()
+	if err != nil {
+		t.Fatalf("error: unexpected error: %v", err)
+	}
+	if len(tests) != 1 {
+		t.Fatalf("unexpected failed tests: want 1; got = %v", tests)
+	}
+	if strings.HasPrefix(tests[0], "[It] ") {
+		t.Fatalf("unexpected failed test name: %v", tests[0])
+	}
+}
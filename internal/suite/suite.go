@@ -21,28 +21,26 @@ import (
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
+	"io"
+	"io/fs"
 	"net/mail"
 	"net/url"
-	"os"
 	"path"
 	"regexp"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/cucumber/godog"
-	semver "github.com/hashicorp/go-version"
 	githubql "github.com/shurcooL/githubv4"
 	sonobuoyresults "github.com/vmware-tanzu/sonobuoy/pkg/client/results"
 	"sigs.k8s.io/yaml"
 
 	"sigs.k8s.io/verify-conformance/internal/common"
+	"sigs.k8s.io/verify-conformance/internal/resolver"
 	"sigs.k8s.io/verify-conformance/internal/types"
 )
 
-var (
-	lastSupportingVersions = 2
-)
-
 type ResultPrepare struct {
 	Name  string
 	Hints []string
@@ -70,8 +68,11 @@ type PullRequestQuery struct {
 			Path githubql.String
 		}
 	} `graphql:"files(first:10)"`
-	Title   githubql.String
-	Commits struct {
+	Title       githubql.String
+	IsDraft     githubql.Boolean
+	BaseRefName githubql.String
+	BaseRefOID  githubql.String
+	Commits     struct {
 		Nodes []struct {
 			Commit struct {
 				Oid    githubql.String
@@ -99,6 +100,12 @@ type PullRequest struct {
 	Labels                  []string
 	SupportingFiles         []*PullRequestFile
 	ProductYAMLURLDataTypes map[string]string
+	// ProductYAMLURLResults are how the URL fields in PRODUCT.yaml resolved, by field
+	ProductYAMLURLResults map[string]*resolver.Result
+	ProductLogo           *ProductLogo
+	// ExistingSubmissions are the submissions for the same release on the base branch,
+	// nil when the base branch has not been looked up
+	ExistingSubmissions []*ExistingSubmission
 }
 
 type ConformanceTestMetadata struct {
@@ -150,7 +157,10 @@ type JunitTestSuitesv125 struct {
 }
 
 type PRSuiteOptions struct {
+	// Paths are the feature files or folders of them in the DataFS of the suite, FeaturesFolder by default
 	Paths []string
+	// Tags filters the scenarios to run, e.g. "~@github" to skip scenarios needing a PR on GitHub
+	Tags string
 }
 
 type PRSuite struct {
@@ -161,7 +171,14 @@ type PRSuite struct {
 	MissingFiles                   []string
 	E2eLogKubernetesReleaseVersion string
 	Labels                         []string
-
+	// ReleaseLifecycle is which releases are accepted, only the newest DefaultSupportedMinors when nil
+	ReleaseLifecycle *ReleaseLifecycle
+	// Now returns the time the release lifecycle is evaluated at
+	Now func() time.Time
+
+	// DataFS has the features, schemas and conformance metadata, laid out like kodata
+	DataFS fs.FS
+	// MetadataFolder is the folder in DataFS with a conformance.yaml in a folder for each release
 	MetadataFolder string
 	Suite          godog.TestSuite
 	buffer         bytes.Buffer
@@ -171,21 +188,28 @@ func NewPRSuite(PR *PullRequest) *PRSuite {
 	return &PRSuite{
 		PR:     PR,
 		Labels: []string{"conformance-product-submission"},
+		Now:    time.Now,
 
-		MetadataFolder: path.Join(os.Getenv("KO_DATA_PATH"), "conformance-testdata"),
+		DataFS:         common.GetDataFS(),
+		MetadataFolder: ConformanceMetadataFolder,
 		buffer:         *bytes.NewBuffer(nil),
 	}
 }
 
 func (s *PRSuite) NewTestSuite(opts PRSuiteOptions) godog.TestSuite {
+	paths := opts.Paths
+	if len(paths) == 0 {
+		paths = []string{FeaturesFolder}
+	}
 	s.Suite = godog.TestSuite{
 		Name: "how-are-the-prs",
 		Options: &godog.Options{
 			// Format: "pretty",
 			Format: "cucumber",
 			Output: &s.buffer,
-			Paths:  opts.Paths,
-			// TODO: add tags filtering
+			FS:     s.DataFS,
+			Paths:  paths,
+			Tags:   opts.Tags,
 		},
 		ScenarioInitializer: s.InitializeScenario,
 	}
@@ -221,7 +245,7 @@ func (s *PRSuite) isIncludedInItsFileList(fileName string) error {
 }
 
 func (s *PRSuite) fileFolderStructureMatchesRegex(match string) error {
-	pattern := regexp.MustCompile(match)
+	pattern := regexp.MustCompile(expandReleaseVersionPattern(match))
 	failureError := fmt.Errorf("your product submission PR must be in folders structured like [KubernetesReleaseVersion]/[ProductName], e.g: v1.23/averycooldistro")
 	for _, file := range s.PR.SupportingFiles {
 		if matches := pattern.MatchString(path.Dir(file.Name)); !matches {
@@ -235,6 +259,9 @@ func (s *PRSuite) fileFolderStructureMatchesRegex(match string) error {
 			if baseFolder == "" || distroName == "" {
 				return failureError
 			}
+			if releaseVersion, err := ParseReleaseVersion(baseFolder); err == nil && releaseVersion.HasPatch {
+				return common.SafeError(fmt.Errorf("file '%v' not allowed. the release folder '%v' must be the minor release version '%v'", file.Name, baseFolder, releaseVersion.MinorVersion()))
+			}
 		}
 	}
 	return nil
@@ -272,7 +299,7 @@ func (s *PRSuite) theTitleOfThePR() error {
 }
 
 func (s *PRSuite) theTitleOfThePRMatches(match string) error {
-	pattern := regexp.MustCompile(match)
+	pattern := regexp.MustCompile(expandReleaseVersionPattern(match))
 	if !pattern.MatchString(string(s.PR.Title)) {
 		return common.SafeError(fmt.Errorf("title must be formatted like 'Conformance results for [KubernetesReleaseVersion]/[ProductName]' (e.g: Conformance results for v1.23/CoolKubernetes)"))
 	}
@@ -323,22 +350,6 @@ func (s *PRSuite) GetFileByFileName(fileName string) *PullRequestFile {
 	return nil
 }
 
-func (s *PRSuite) theYamlFileContainsTheRequiredAndNonEmptyField(fileName, fieldName string) error {
-	var parsedContent map[string]*interface{}
-	file := s.GetFileByFileName(fileName)
-	if file == nil {
-		return common.SafeError(fmt.Errorf("missing required file '%v'", fileName))
-	}
-	err := yaml.Unmarshal([]byte(file.Contents), &parsedContent)
-	if err != nil {
-		return common.SafeError(fmt.Errorf("unable to read file '%v'", fileName))
-	}
-	if parsedContent[fieldName] == nil {
-		return common.SafeError(fmt.Errorf("missing or empty field '%v' in file '%v'", fieldName, fileName))
-	}
-	return nil
-}
-
 func (s *PRSuite) isNotEmpty(fileName string) error {
 	file := s.GetFileByFileName(fileName)
 	if file == nil {
@@ -386,27 +397,22 @@ func (s *PRSuite) theLabelPrefixedWithAndEndingWithKubernetesReleaseVersionShoul
 }
 
 func (s *PRSuite) theContentOfTheInTheValueOfIsAValid(fieldType string, field string) error {
-	fileName := "PRODUCT.yaml"
-	var parsedContent map[string]string
-	file := s.GetFileByFileName(fileName)
-	if file == nil {
-		return common.SafeError(fmt.Errorf("missing required file '%v'", fileName))
-	}
-	err := yaml.Unmarshal([]byte(file.Contents), &parsedContent)
+	productYAML, err := s.getProductYAML()
 	if err != nil {
-		return common.SafeError(fmt.Errorf("unable to read file '%v'", fileName))
+		return err
 	}
-	if parsedContent[field] == "" {
+	value := productYAML.Fields()[field]
+	if value == "" {
 		return nil
 	}
 	switch fieldType {
 	case "URL":
-		_, err = url.ParseRequestURI(parsedContent[field])
+		_, err = url.ParseRequestURI(value)
 		if err != nil {
 			return common.SafeError(fmt.Errorf("URL for field '%v' in PRODUCT.yaml is not a valid URL, %v", field, err))
 		}
 	case "email":
-		_, err = mail.ParseAddress(parsedContent[field])
+		_, err = mail.ParseAddress(value)
 		if err != nil {
 			return common.SafeError(fmt.Errorf("email field '%v' in PRODUCT.yaml is not a valid address, %v", field, err))
 		}
@@ -415,7 +421,15 @@ func (s *PRSuite) theContentOfTheInTheValueOfIsAValid(fieldType string, field st
 }
 
 func (s *PRSuite) theContentOfTheUrlInTheValueOfMatches(field, dataType string) error {
-	if s.PR.ProductYAMLURLDataTypes[field] == "" {
+	result := s.PR.ProductYAMLURLResults[field]
+	if result != nil {
+		if result.Error != "" {
+			return common.SafeError(fmt.Errorf("URL field '%v' in PRODUCT.yaml is unable to be resolved%v, %v", field, describeRedirects(result), result.Error))
+		}
+		if result.Insecure != "" {
+			return common.SafeError(fmt.Errorf("URL field '%v' in PRODUCT.yaml%v is served with a TLS certificate which is not valid (%v); it must be resolvable without skipping verification", field, describeRedirects(result), result.Insecure))
+		}
+	} else if s.PR.ProductYAMLURLDataTypes[field] == "" {
 		return nil
 	}
 	foundDataType := false
@@ -426,71 +440,44 @@ func (s *PRSuite) theContentOfTheUrlInTheValueOfMatches(field, dataType string)
 		}
 	}
 	if !foundDataType {
-		return common.SafeError(fmt.Errorf("URL field '%v' in PRODUCT.yaml resolving content type '%v' must be (%v)", field, s.PR.ProductYAMLURLDataTypes[field], strings.Join(strings.Split(dataType, " "), ", or ")))
+		return common.SafeError(fmt.Errorf("URL field '%v' in PRODUCT.yaml%v resolving content type '%v' must be (%v)", field, describeRedirects(result), s.PR.ProductYAMLURLDataTypes[field], strings.Join(strings.Split(dataType, " "), ", or ")))
 	}
 	return nil
 }
 
-func (s *PRSuite) theFieldMatchesOneOfTheFollowingValues(field string, valuesRaw string) error {
-	fileName := "PRODUCT.yaml"
-	var parsedContent map[string]string
-	file := s.GetFileByFileName(fileName)
-	if file == nil {
-		return common.SafeError(fmt.Errorf("missing required file '%v'", fileName))
-	}
-	err := yaml.Unmarshal([]byte(file.Contents), &parsedContent)
-	if err != nil {
-		return common.SafeError(fmt.Errorf("unable to read file '%v'", fileName))
-	}
-	if parsedContent[field] == "" {
-		return common.SafeError(fmt.Errorf("missing required field '%v' in '%v'", field, fileName))
-	}
-	values := strings.Split(valuesRaw, ", ")
-	found := false
-	for _, val := range values {
-		if val == parsedContent[field] {
-			found = true
-		}
+// describeRedirects returns the redirect chain of a resolved URL for an error, if there is one
+func describeRedirects(result *resolver.Result) string {
+	if result == nil || len(result.Redirects) == 0 {
+		return ""
 	}
-	if !found {
-		return common.SafeError(fmt.Errorf("field '%v' in '%v' is not valid and must be one of the following: %v", field, fileName, valuesRaw))
-	}
-	return nil
+	return fmt.Sprintf(" (redirected %v -> %v)", result.URL, strings.Join(result.Redirects, " -> "))
 }
 
 func (s *PRSuite) SetSubmissionMetadatafromFolderStructure() *PRSuite {
-	pattern := regexp.MustCompile(`(v1.[0-9]{2})/(.*)/.*`)
-
-filesLoop:
 	for _, file := range s.PR.SupportingFiles {
-		allIndexes := pattern.FindAllSubmatchIndex([]byte(file.Name), -1)
-		for _, loc := range allIndexes {
-			loc := loc
-			releaseVersion := string(file.Name[loc[2]:loc[3]])
-			distroName := string(file.Name[loc[4]:loc[5]])
-			s.KubernetesReleaseVersion = releaseVersion
-			s.ProductName = distroName
-			break filesLoop
+		m := submissionFolderRegexp.FindStringSubmatch(file.Name)
+		if m == nil {
+			continue
+		}
+		releaseVersion, err := ParseReleaseVersion(m[1])
+		if err != nil {
+			continue
 		}
+		s.KubernetesReleaseVersion = releaseVersion.MinorVersion()
+		s.ProductName = m[2]
+		break
 	}
 	return s
 }
 
 func (s *PRSuite) theReleaseVersionMatchesTheReleaseVersionInTheTitle() error {
-	pattern := regexp.MustCompile(`(.*) (v1.[0-9]{2})[ /](.*)`)
-
-	var titleReleaseVersion string
-	allIndexes := pattern.FindAllSubmatchIndex([]byte(s.PR.Title), -1)
-	for _, loc := range allIndexes {
-		//nolint:typecheck
-		loc := loc
-		titleReleaseVersion = string(s.PR.Title[loc[4]:loc[5]])
-		if titleReleaseVersion != "" {
-			break
+	_, titleReleaseVersion, _ := s.titleParts()
+	if titleReleaseVersion == nil || titleReleaseVersion.MinorVersion() != s.KubernetesReleaseVersion {
+		title := ""
+		if titleReleaseVersion != nil {
+			title = titleReleaseVersion.String()
 		}
-	}
-	if titleReleaseVersion != s.KubernetesReleaseVersion {
-		return common.SafeError(fmt.Errorf("the Kubernetes release version in the title (%v) and folder structure (%v) don't match", titleReleaseVersion, s.KubernetesReleaseVersion))
+		return common.SafeError(fmt.Errorf("the Kubernetes release version in the title (%v) and folder structure (%v) don't match", title, s.KubernetesReleaseVersion))
 	}
 	return nil
 }
@@ -503,27 +490,16 @@ func (s *PRSuite) theReleaseVersion() error {
 }
 
 func (s *PRSuite) itIsAValidAndSupportedRelease() error {
-	latestVersion, err := semver.NewSemver(s.KubernetesReleaseVersionLatest)
-	if err != nil {
-		fmt.Printf("error with go-version parsing latestVersion '%v': %v\n", s.KubernetesReleaseVersionLatest, err)
-		return common.SafeError(fmt.Errorf("unable to parse latest release version"))
-	}
-	currentVersion, err := semver.NewSemver(s.KubernetesReleaseVersion)
+	support, err := s.releaseSupport()
 	if err != nil {
-		fmt.Printf("error with go-version parsing currentVersion '%v': %v\n", currentVersion, err)
-		return common.SafeError(fmt.Errorf("unable to parse release version"))
-	}
-	latestVersionSegments := latestVersion.Segments()
-	latestVersionSegments[1] -= lastSupportingVersions
-	oldestVersion := fmt.Sprintf("v%v.%v", latestVersionSegments[0], latestVersionSegments[1])
-	oldestSupportedVersion, err := semver.NewSemver(oldestVersion)
-	if err != nil {
-		fmt.Printf("error with go-version parsing oldest release version '%v': %v\n", latestVersionSegments, err)
-		return common.SafeError(fmt.Errorf("unable to parse oldest supported release version"))
+		return err
 	}
-
-	if currentVersion.LessThan(oldestSupportedVersion) {
-		return common.SafeError(fmt.Errorf("unable to use version %v because it is older than the last currently supported release %v", s.KubernetesReleaseVersion, oldestVersion))
+	if !support.Supported {
+		message := fmt.Sprintf("unable to use version %v because it is older than the last currently supported release %v", s.KubernetesReleaseVersion, support.Oldest.MinorVersion())
+		if end := s.describeReleaseSupportEnd(support); end != "" {
+			message += "; " + end
+		}
+		return common.SafeError(fmt.Errorf("%v", message))
 	}
 	return nil
 }
@@ -532,34 +508,38 @@ func (s *PRSuite) ItIsAValidAndSupportedRelease() error {
 	return s.itIsAValidAndSupportedRelease()
 }
 
+// ReadConformanceYAML returns the conformance.yaml of the release version of the submission
+func (s *PRSuite) ReadConformanceYAML() ([]byte, error) {
+	return fs.ReadFile(s.DataFS, path.Join(s.MetadataFolder, s.KubernetesReleaseVersion, "conformance.yaml"))
+}
+
+// GetRequiredTests returns the conformance tests required for the release version of the submission,
+// being those added in that release or before it
 func (s *PRSuite) GetRequiredTests() (tests map[string]bool, err error) {
-	versionSemver, err := semver.NewSemver(s.KubernetesReleaseVersion)
+	version, err := ParseReleaseVersion(s.KubernetesReleaseVersion)
 	if err != nil {
 		return map[string]bool{}, err
 	}
 	var conformanceMetadata []ConformanceTestMetadata
-	content, err := common.ReadFile(path.Join(s.MetadataFolder, s.KubernetesReleaseVersion, "conformance.yaml"))
+	content, err := s.ReadConformanceYAML()
 	if err != nil {
 		return map[string]bool{}, err
 	}
-	err = yaml.Unmarshal([]byte(content), &conformanceMetadata)
+	err = yaml.Unmarshal(content, &conformanceMetadata)
 	if err != nil {
 		return map[string]bool{}, err
 	}
 	tests = map[string]bool{}
 	for _, test := range conformanceMetadata {
 		foundInTestVersions := false
-	testSupportedVersions:
 		for _, r := range strings.Split(test.Release, ",") {
-			testVersionSemver, err := semver.NewSemver(r)
+			testVersion, err := parseConformanceTestRelease(r)
 			if err != nil {
 				return map[string]bool{}, err
 			}
-			if versionSemver.GreaterThanOrEqual(testVersionSemver) {
+			if compareMinorVersions(version, testVersion) >= 0 {
 				foundInTestVersions = true
-			}
-			if foundInTestVersions {
-				break testSupportedVersions
+				break
 			}
 		}
 		if !foundInTestVersions {
@@ -570,6 +550,16 @@ func (s *PRSuite) GetRequiredTests() (tests map[string]bool, err error) {
 	return tests, nil
 }
 
+// parseConformanceTestRelease parses a release a test was added or changed in, from the conformance.yaml
+// of Kubernetes, which writes some of them with spaces or without the leading v, like "1.25"
+func parseConformanceTestRelease(release string) (*ReleaseVersion, error) {
+	release = strings.TrimSpace(release)
+	if !strings.HasPrefix(release, "v") {
+		release = "v" + release
+	}
+	return ParseReleaseVersion(release)
+}
+
 func (s *PRSuite) getJunitSubmittedConformanceTests() (tests []sonobuoyresults.JUnitTestCase, err error) {
 	file := s.GetFileByFileName("junit_01.xml")
 	if file == nil {
@@ -620,7 +610,6 @@ func (s *PRSuite) GetMissingJunitTestsFromPRSuite() (missingTests []string, err
 	if err != nil {
 		return []string{}, err
 	}
-	fmt.Println("submitted tests count:", len(submittedTests))
 
 	for _, submittedTest := range submittedTests {
 		submittedTest = strings.TrimPrefix(submittedTest, "[It] ")
@@ -689,7 +678,7 @@ func (s *PRSuite) theTestsPassAndAreSuccessful() error {
 	}
 	if !success {
 		s.Labels = append(s.Labels, "evidence-missing")
-		return common.SafeError(fmt.Errorf("it appears that there are failures in some tests"))
+		return common.SafeError(fmt.Errorf("it appears that there are failures in some tests in the junit_01.xml"))
 	}
 	s.Labels = append(s.Labels, "no-failed-tests-"+s.KubernetesReleaseVersion)
 	return nil
@@ -735,6 +724,95 @@ func IsValidYaml(input []byte) error {
 	return nil
 }
 
+// IsValidXml returns an error when input isn't well-formed XML with a root element
+func IsValidXml(input []byte) error {
+	_, err := xmlRootElement(input)
+	return err
+}
+
+// xmlRootElement returns the name of the root element, reading all of input to ensure it is well-formed
+func xmlRootElement(input []byte) (root string, err error) {
+	decoder := xml.NewDecoder(bytes.NewReader(input))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		if element, ok := token.(xml.StartElement); ok && root == "" {
+			root = element.Name.Local
+		}
+	}
+	if root == "" {
+		return "", fmt.Errorf("no root element found")
+	}
+	return root, nil
+}
+
+type junitXmlTestSuites struct {
+	Tests    *int                `xml:"tests,attr"`
+	Failures *int                `xml:"failures,attr"`
+	Suites   []junitXmlTestSuite `xml:"testsuite"`
+}
+
+type junitXmlTestSuite struct {
+	Name      string                          `xml:"name,attr"`
+	Tests     *int                            `xml:"tests,attr"`
+	Failures  *int                            `xml:"failures,attr"`
+	TestCases []sonobuoyresults.JUnitTestCase `xml:"testcase"`
+}
+
+// IsValidJunitXml returns an error describing what is wrong when input isn't a junit report of a conformance run.
+// The tests and failures attributes, where set, must agree with the testcase elements.
+func IsValidJunitXml(input []byte) error {
+	root, err := xmlRootElement(input)
+	if err != nil {
+		return err
+	}
+	if root != "testsuites" {
+		return fmt.Errorf("the root element is <%v>, expected <testsuites>", root)
+	}
+	junit := junitXmlTestSuites{}
+	if err := xml.Unmarshal(input, &junit); err != nil {
+		return err
+	}
+	if len(junit.Suites) == 0 {
+		return fmt.Errorf("no <testsuite> elements found in <testsuites>")
+	}
+	tests, failures, conformanceTests := 0, 0, 0
+	for _, suite := range junit.Suites {
+		suiteFailures := 0
+		for _, testcase := range suite.TestCases {
+			if testcase.Failure != nil {
+				suiteFailures++
+			}
+			if testcase.SkipMessage == nil && strings.Contains(testcase.Name, "[Conformance]") {
+				conformanceTests++
+			}
+		}
+		if suite.Tests != nil && *suite.Tests != len(suite.TestCases) {
+			return fmt.Errorf("the tests attribute of <testsuite name=%q> (%v) does not match the number of <testcase> elements (%v)", suite.Name, *suite.Tests, len(suite.TestCases))
+		}
+		if suite.Failures != nil && *suite.Failures != suiteFailures {
+			return fmt.Errorf("the failures attribute of <testsuite name=%q> (%v) does not match the number of <testcase> elements with a <failure> (%v)", suite.Name, *suite.Failures, suiteFailures)
+		}
+		tests += len(suite.TestCases)
+		failures += suiteFailures
+	}
+	if junit.Tests != nil && *junit.Tests != tests {
+		return fmt.Errorf("the tests attribute of <testsuites> (%v) does not match the number of <testcase> elements (%v)", *junit.Tests, tests)
+	}
+	if junit.Failures != nil && *junit.Failures != failures {
+		return fmt.Errorf("the failures attribute of <testsuites> (%v) does not match the number of <testcase> elements with a <failure> (%v)", *junit.Failures, failures)
+	}
+	if conformanceTests == 0 {
+		return fmt.Errorf("no [Conformance] tests were run")
+	}
+	return nil
+}
+
 func (s *PRSuite) IsValid(fileName, fileType string) error {
 	file := s.GetFileByFileName(fileName)
 	if file == nil {
@@ -748,7 +826,20 @@ func (s *PRSuite) IsValid(fileName, fileType string) error {
 		if err := IsValidYaml([]byte(file.Contents)); err != nil {
 			return common.SafeError(fmt.Errorf("failed to parse (%v) YAML, %v", fileName, err))
 		}
-		// TODO: add xml parsing
+	case "markdown":
+		if _, err := ParseMarkdown(file.Contents); err != nil {
+			return common.SafeError(fmt.Errorf("failed to parse (%v) markdown, %v", fileName, err))
+		}
+	case "xml":
+		if err := IsValidXml([]byte(file.Contents)); err != nil {
+			return common.SafeError(fmt.Errorf("failed to parse (%v) XML, %v", fileName, err))
+		}
+		if fileName != "junit_01.xml" {
+			break
+		}
+		if err := IsValidJunitXml([]byte(file.Contents)); err != nil {
+			return common.SafeError(fmt.Errorf("(%v) is not a valid junit report of a conformance test run, %v", fileName, err))
+		}
 	}
 	return nil
 }
@@ -763,18 +854,23 @@ func (s *PRSuite) GetLabelsAndCommentsFromSuiteResultsBuffer() (comment string,
 	if err != nil {
 		return "", []string{}, "", err
 	}
-	releaseVersion, err := semver.NewSemver(s.KubernetesReleaseVersion)
+	releaseVersion, err := ParseReleaseVersion(s.KubernetesReleaseVersion)
 	if err != nil {
 		return "", []string{}, "", err
 	}
-	releaseVersionLatest, err := semver.NewSemver(s.KubernetesReleaseVersionLatest)
+	releaseVersionLatest, err := ParseReleaseVersion(s.KubernetesReleaseVersionLatest)
 	if err != nil {
 		return "", []string{}, "", err
 	}
-	if releaseVersion.GreaterThanOrEqual(releaseVersionLatest) {
-		_, err = common.ReadFile(path.Join(s.MetadataFolder, s.KubernetesReleaseVersion, "conformance.yaml"))
+	// the conformance metadata of the latest release, or a newer one, may not be available yet
+	if compareMinorVersions(releaseVersion, releaseVersionLatest) >= 0 {
+		_, err = s.ReadConformanceYAML()
 		if err != nil {
-			return fmt.Sprintf("The release version %v is unable to be processed at this time; Please wait as this version may become available soon.", s.KubernetesReleaseVersion), append(labels, "conformance-product-submission", "unable-to-process"), "pending", nil
+			comment, err := s.RenderComment(CommentTemplateUnableToProcess, s.NewCommentData())
+			if err != nil {
+				return "", []string{}, "", err
+			}
+			return comment, append(labels, "conformance-product-submission", "unable-to-process"), "pending", nil
 		}
 	}
 	uniquelyNamedStepsRun := []string{}
@@ -825,27 +921,28 @@ func (s *PRSuite) GetLabelsAndCommentsFromSuiteResultsBuffer() (comment string,
 		}
 	}
 
-	finalComment := fmt.Sprintf("All requirements (%v) have passed for the submission!", len(uniquelyNamedStepsRun))
 	state = "success"
 	// TODO use prSuite.Labels
 	if s.KubernetesReleaseVersion != "" {
 		s.Labels = append(s.Labels, "release-"+s.KubernetesReleaseVersion)
 	}
 	if len(resultPrepares) > 0 {
-		finalComment = fmt.Sprintf("%v of %v requirements have passed. Please review the following:", len(uniquelyNamedStepsRun)-len(resultPrepares), len(uniquelyNamedStepsRun))
-		for _, r := range resultPrepares {
-			finalComment += "\n- [FAIL] " + r.Name
-			for _, h := range r.Hints {
-				finalComment += "\n  - " + h
-			}
-		}
-		finalComment += "\n\n for a full list of requirements, please refer to these sections of the docs: [_content of the PR_](https://github.com/cncf/k8s-conformance/blob/master/instructions.md#contents-of-the-pr), and [_requirements_](https://github.com/cncf/k8s-conformance/blob/master/instructions.md#requirements)."
 		s.Labels = append(s.Labels, "not-verifiable")
 		state = "failure"
 	} else {
 		s.Labels = append(s.Labels, "release-documents-checked")
 	}
-	finalComment += "\n"
+
+	data := s.NewCommentData()
+	data.Requirements = len(uniquelyNamedStepsRun)
+	data.Passed = len(uniquelyNamedStepsRun) - len(resultPrepares)
+	data.Failures = resultPrepares
+	data.Scenarios = scenarioResultsFromCukeFeatures(cukeFeatures)
+	data.MissingTests, data.FailedTests = s.junitTestResults()
+	finalComment, err := s.RenderComment(CommentTemplateResults, data)
+	if err != nil {
+		return "", []string{}, "", err
+	}
 
 	return finalComment, s.Labels, state, nil
 }
@@ -862,10 +959,8 @@ func (s *PRSuite) InitializeScenario(ctx *godog.ScenarioContext) {
 	ctx.Step(`^"([^"]*)" is not empty$`, s.isNotEmpty)
 	ctx.Step(`^a list of labels in the PR$`, s.aListOfLabelsInThePR)
 	ctx.Step(`^the label prefixed with "([^"]*)" and ending with Kubernetes release version should be present$`, s.theLabelPrefixedWithAndEndingWithKubernetesReleaseVersionShouldBePresent)
-	ctx.Step(`^the yaml file "([^"]*)" contains the required and non-empty "([^"]*)"$`, s.theYamlFileContainsTheRequiredAndNonEmptyField)
 	ctx.Step(`^the content of the "([^"]*)" in the value of "([^"]*)" is a valid .*$`, s.theContentOfTheInTheValueOfIsAValid)
 	ctx.Step(`^the content of the url in the value of "([^"]*)" matches it\'s "([^"]*)"$`, s.theContentOfTheUrlInTheValueOfMatches)
-	ctx.Step(`^the field "([^"]*)" matches one of the following values: "([^"]*)"$`, s.theFieldMatchesOneOfTheFollowingValues)
 	ctx.Step(`^there is only one path of folders$`, s.thereIsOnlyOnePathOfFolders)
 	ctx.Step(`^the release version matches the release version in the title$`, s.theReleaseVersionMatchesTheReleaseVersionInTheTitle)
 	ctx.Step(`^the release version$`, s.theReleaseVersion)
@@ -873,6 +968,13 @@ func (s *PRSuite) InitializeScenario(ctx *godog.ScenarioContext) {
 	ctx.Step(`^the tests pass and are successful$`, s.theTestsPassAndAreSuccessful)
 	ctx.Step(`^all required tests in junit_01.xml are present$`, s.allRequiredTestsInJunitXmlArePresent)
 	ctx.Step(`^all required tests are present$`, s.allRequiredTestsInArePresent)
+	ctx.Step(`^the Kubernetes release version in the e2e.log matches the release version$`, s.theKubernetesReleaseVersionInTheE2eLogMatchesTheReleaseVersion)
+	ctx.Step(`^the results in the e2e.log match the junit_01.xml$`, s.theResultsInTheE2eLogMatchTheJunitXml)
+	ctx.Step(`^the README.md contains instructions to reproduce the results$`, s.theReadmeContainsInstructionsToReproduceTheResults)
+	ctx.Step(`^the PRODUCT.yaml is valid against its schema$`, s.theProductYamlIsValidAgainstItsSchema)
+	ctx.Step(`^the product logo is a safe SVG$`, s.theProductLogoIsASafeSVG)
+	ctx.Step(`^the submission does not overwrite or duplicate an existing submission$`, s.theSubmissionDoesNotOverwriteOrDuplicateAnExistingSubmission)
+	ctx.Step(`^the product name in the title, folder structure and PRODUCT.yaml match$`, s.theProductNameInTheTitleFolderStructureAndProductYamlMatch)
 	ctx.Step(`^a PR title$`, aPRTitle)
 	ctx.Step(`^"([^"]*)" is valid "([^"]*)"`, s.IsValid)
 	ctx.Step(`^a list of commits$`, s.aListOfCommits)
@@ -19,9 +19,8 @@ package suite
 import (
 	"bytes"
 	_ "embed"
-	"log"
+	"html"
 	"os"
-	"path"
 	"reflect"
 	"strings"
 	"testing"
@@ -29,6 +28,7 @@ import (
 	githubql "github.com/shurcooL/githubv4"
 
 	"sigs.k8s.io/verify-conformance/internal/common"
+	"sigs.k8s.io/verify-conformance/internal/resolver"
 )
 
 // TODO(BobyMCbobs): add Gomega https://onsi.github.io/gomega/
@@ -42,14 +42,12 @@ var (
 	testGetJunitSubmittedConformanceTestsCoolkubeV133Junit_01WithOneTestMissingxml string
 	//go:embed testdata/TestGetJunitSubmittedConformanceTests-coolkube-v1-35-junit_01-with-1-extra-test.xml
 	testGetJunitSubmittedConformanceTestsCoolkubeV133Junit_01xmlWithOneExtraTest string
+	//go:embed testdata/TestParseE2eLog-coolkube-v1-35-e2e.log
+	testParseE2eLogCoolkubeV135E2eLog string
+	//go:embed testdata/TestReadme-coolkube-v1-35-README.md
+	testReadmeCoolkubeV135README string
 )
 
-func init() {
-	if err := os.Setenv("KO_DATA_PATH", "./../../kodata"); err != nil {
-		log.Fatalf("failed to set env: %v", err)
-	}
-}
-
 func TestNewPRSuite(t *testing.T) {
 	for _, pr := range []*PullRequest{
 		{
@@ -156,7 +154,7 @@ func TestNewPRSuite(t *testing.T) {
 		if len(prSuite.Labels) != 1 {
 			t.Fatalf("error: PR must start with one label (%v)", prSuite.Labels)
 		}
-		if prSuite.MetadataFolder != path.Join(".", "..", "..", "kodata", "conformance-testdata") {
+		if prSuite.MetadataFolder != ConformanceMetadataFolder {
 			t.Fatalf("error: metadata folder not as expected (%v)", prSuite.MetadataFolder)
 		}
 		if prSuite.buffer.Len() != 0 {
@@ -334,7 +332,7 @@ func TestFileFolderStructureMatchesRegex(t *testing.T) {
 		ExpectedErrorString string
 	}
 
-	folderStructureRegexp := `(v1.[0-9]{2})/(.*)`
+	folderStructureRegexp := `({release})/(.*)`
 
 	for _, tc := range []testSuite{
 		{
@@ -356,6 +354,17 @@ func TestFileFolderStructureMatchesRegex(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "invalid file paths with a patch release folder",
+			PullRequest: &PullRequest{
+				SupportingFiles: []*PullRequestFile{
+					{
+						Name: "v1.35.2/coolkube/README.md",
+					},
+				},
+			},
+			ExpectedErrorString: "the release folder &#39;v1.35.2&#39; must be the minor release version &#39;v1.35&#39;",
+		},
 		{
 			Name: "invalid file paths with edit outside pr",
 			PullRequest: &PullRequest{
@@ -545,7 +554,7 @@ func TestTheTitleOfThePRMatches(t *testing.T) {
 		PullRequest         *PullRequest
 		ExpectedErrorString string
 	}
-	titleRegexp := `(.*) (v1.[0-9]{2})[ /](.*)`
+	titleRegexp := `(.*) ({release})[ /](.*)`
 
 	for _, tc := range []testSuite{
 		{
@@ -556,6 +565,22 @@ func TestTheTitleOfThePRMatches(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "valid title with a patch release",
+			PullRequest: &PullRequest{
+				PullRequestQuery: PullRequestQuery{
+					Title: githubql.String("Conformance results for v1.35.2 CoolKube"),
+				},
+			},
+		},
+		{
+			Name: "valid title with a future major release",
+			PullRequest: &PullRequest{
+				PullRequestQuery: PullRequestQuery{
+					Title: githubql.String("Conformance results for v2.0/coolkube"),
+				},
+			},
+		},
 		{
 			Name: "invalid title without period in version",
 			PullRequest: &PullRequest{
@@ -719,102 +744,6 @@ func TestGetFileByFileName(t *testing.T) {
 	}
 }
 
-func TestTheYamlFileContainsTheRequiredAndNonEmptyField(t *testing.T) {
-	type testCase struct {
-		Name                string
-		PullRequest         *PullRequest
-		ExpectedErrorString string
-	}
-	requiredKeys := []string{"vendor", "name", "version", "type", "description", "website_url", "documentation_url", "contact_email_address"}
-	for _, tc := range []testCase{
-		{
-			Name: "valid PRODUCT.yaml",
-			PullRequest: &PullRequest{
-				SupportingFiles: []*PullRequestFile{
-					{
-						Name:     "v1.35/coolkube/PRODUCT.yaml",
-						BaseName: "PRODUCT.yaml",
-						Contents: `vendor: "cool"
-name: "coolkube"
-version: "v1.35"
-type: "distribution"
-description: "it's just all-round cool and probably the best k8s, idk"
-website_url: "https://coolkubernetes.com"
-documentation_url: "https://coolkubernetes.com/docs"
-contact_email_address: "sales@coolkubernetes.com"`,
-					},
-				},
-			},
-		},
-		{
-			Name: "invalid PRODUCT.yaml missing documentation_url",
-			PullRequest: &PullRequest{
-				SupportingFiles: []*PullRequestFile{
-					{
-						Name:     "v1.35/coolkube/PRODUCT.yaml",
-						BaseName: "PRODUCT.yaml",
-						Contents: `vendor: "cool"
-name: "coolkube"
-version: "v1.35"
-type: "distribution"
-description: "it's just all-round cool and probably the best k8s, idk"
-website_url: "https://coolkubernetes.com"
-contact_email_address: "sales@coolkubernetes.com"`,
-					},
-				},
-			},
-			ExpectedErrorString: "missing or empty field &#39;documentation_url&#39;",
-		},
-		{
-			Name: "invalid PRODUCT.yaml missing many fields",
-			PullRequest: &PullRequest{
-				SupportingFiles: []*PullRequestFile{
-					{
-						Name:     "v1.35/coolkube/PRODUCT.yaml",
-						BaseName: "PRODUCT.yaml",
-						Contents: `vendor: "cool"
-name: "coolkube"
-version: "v1.35"
-description: "it's just all-round cool and probably the best k8s, idk"
-website_url: "https://coolkubernetes.com"
-contact_email_address: "sales@coolkubernetes.com"`,
-					},
-				},
-			},
-			ExpectedErrorString: "missing or empty field",
-		},
-		{
-			Name:                "missing PRODUCT.yaml",
-			ExpectedErrorString: "missing required file",
-			PullRequest:         &PullRequest{},
-		},
-		{
-			Name:                "invalid PRODUCT.yaml unable to parse",
-			ExpectedErrorString: "unable to read file",
-			PullRequest: &PullRequest{
-				SupportingFiles: []*PullRequestFile{
-					{
-						Name:     "v1.35/coolkube/PRODUCT.yaml",
-						BaseName: "PRODUCT.yaml",
-						Contents: `v"`,
-					},
-				},
-			},
-		},
-	} {
-		prSuite := NewPRSuite(tc.PullRequest)
-	k:
-		for _, k := range requiredKeys {
-			err := prSuite.theYamlFileContainsTheRequiredAndNonEmptyField("PRODUCT.yaml", k)
-			if err != nil && strings.Contains(err.Error(), tc.ExpectedErrorString) {
-				continue k
-			} else if err != nil {
-				t.Fatalf("error: %v", err)
-			}
-		}
-	}
-}
-
 func TestIsNotEmpty(t *testing.T) {
 	type testCase struct {
 		BaseName              string
@@ -1405,122 +1334,57 @@ contact_email_address: "greetings@cool.kube"`
 	}
 }
 
-func TestTheTypeFieldInPRODUCTyamlIsValid(t *testing.T) {
+func TestTheContentOfTheUrlInTheValueOfMatchesWithResults(t *testing.T) {
 	type testCase struct {
-		Name          string
-		Field         string
-		Values        string
-		PullRequest   *PullRequest
-		ExpectedError bool
+		Name                string
+		Result              *resolver.Result
+		ExpectedErrorString string
 	}
 
 	for _, tc := range []testCase{
 		{
-			Name:   "valid installer",
-			Field:  "type",
-			Values: "installer, distribution, hosted platform",
-			PullRequest: &PullRequest{
-				SupportingFiles: []*PullRequestFile{
-					{
-						BaseName: "PRODUCT.yaml",
-						Contents: `---
-type: "installer"
-`,
-					},
-				},
-			},
+			Name:   "resolved",
+			Result: &resolver.Result{URL: "https://cool.kube", StatusCode: 200, ContentType: "text/html; charset=utf-8"},
 		},
 		{
-			Name:   "valid distribution",
-			Field:  "type",
-			Values: "installer, distribution, hosted platform",
-			PullRequest: &PullRequest{
-				SupportingFiles: []*PullRequestFile{
-					{
-						BaseName: "PRODUCT.yaml",
-						Contents: `---
-type: "distribution"
-`,
-					},
-				},
-			},
-		},
-		{
-			Name:   "valid hosted platform",
-			Field:  "type",
-			Values: "installer, distribution, hosted platform",
-			PullRequest: &PullRequest{
-				SupportingFiles: []*PullRequestFile{
-					{
-						BaseName: "PRODUCT.yaml",
-						Contents: `---
-type: "hosted platform"
-`,
-					},
-				},
-			},
+			Name:                "unable to resolve",
+			Result:              &resolver.Result{URL: "https://cool.kube", StatusCode: 404, ContentType: "text/html", Error: "the server responded with status 404 Not Found"},
+			ExpectedErrorString: "URL field 'website_url' in PRODUCT.yaml is unable to be resolved, the server responded with status 404 Not Found",
 		},
 		{
-			Name:   "invalid type",
-			Field:  "type",
-			Values: "installer, distribution, hosted platform",
-			PullRequest: &PullRequest{
-				SupportingFiles: []*PullRequestFile{
-					{
-						BaseName: "PRODUCT.yaml",
-						Contents: `---
-type: "soup"
-`,
-					},
-				},
-			},
-			ExpectedError: true,
+			Name:                "blocked address",
+			Result:              &resolver.Result{URL: "http://169.254.169.254", Error: resolver.ErrBlockedAddress.Error()},
+			ExpectedErrorString: "is unable to be resolved, the address is private, loopback or link-local",
 		},
 		{
-			Name:   "field not found",
-			Field:  "type",
-			Values: "installer, distribution, hosted platform",
-			PullRequest: &PullRequest{
-				SupportingFiles: []*PullRequestFile{
-					{
-						BaseName: "PRODUCT.yaml",
-						Contents: `---
-soup: "minestrone"
-`,
-					},
-				},
-			},
-			ExpectedError: true,
+			Name:                "insecure",
+			Result:              &resolver.Result{URL: "https://cool.kube", StatusCode: 200, ContentType: "text/html", Insecure: "x509: certificate signed by unknown authority"},
+			ExpectedErrorString: "is served with a TLS certificate which is not valid (x509: certificate signed by unknown authority)",
 		},
 		{
-			Name:   "bad yaml",
-			Field:  "type",
-			Values: "installer, distribution, hosted platform",
-			PullRequest: &PullRequest{
-				SupportingFiles: []*PullRequestFile{
-					{
-						BaseName: "PRODUCT.yaml",
-						Contents: `%^(%^&(%&^%))`,
-					},
-				},
-			},
-			ExpectedError: true,
+			Name:                "no content type",
+			Result:              &resolver.Result{URL: "https://cool.kube", StatusCode: 200},
+			ExpectedErrorString: "resolving content type '' must be (text/html)",
 		},
 		{
-			Name:   "file not found",
-			Field:  "type",
-			Values: "installer, distribution, hosted platform",
-			PullRequest: &PullRequest{
-				SupportingFiles: []*PullRequestFile{},
-			},
-			ExpectedError: true,
+			Name:                "redirected to a different content type",
+			Result:              &resolver.Result{URL: "https://cool.kube", StatusCode: 200, ContentType: "application/pdf", Redirects: []string{"https://cool.kube/docs.pdf"}},
+			ExpectedErrorString: "URL field 'website_url' in PRODUCT.yaml (redirected https://cool.kube -> https://cool.kube/docs.pdf) resolving content type 'application/pdf' must be (text/html)",
 		},
 	} {
-		tc := tc
-		prSuite := NewPRSuite(tc.PullRequest)
-		if err := prSuite.theFieldMatchesOneOfTheFollowingValues(tc.Field, tc.Values); err != nil && (err != nil) != tc.ExpectedError {
-			t.Fatalf("error with PRODUCT.yaml field type is invalid: %v", err)
-		}
+		t.Run(tc.Name, func(t *testing.T) {
+			prSuite := NewPRSuite(&PullRequest{
+				ProductYAMLURLDataTypes: map[string]string{"website_url": tc.Result.ContentType},
+				ProductYAMLURLResults:   map[string]*resolver.Result{"website_url": tc.Result},
+			})
+			err := prSuite.theContentOfTheUrlInTheValueOfMatches("website_url", "text/html")
+			if tc.ExpectedErrorString == "" && err != nil {
+				t.Fatalf("error: unexpected error: %v", err)
+			}
+			if tc.ExpectedErrorString != "" && (err == nil || !strings.Contains(html.UnescapeString(err.Error()), tc.ExpectedErrorString)) {
+				t.Fatalf("error: expected error containing '%v'; got = %v", tc.ExpectedErrorString, err)
+			}
+		})
 	}
 }
 
@@ -1580,6 +1444,21 @@ func TestTheReleaseVersionMatchesTheReleaseVersionInTheTitle(t *testing.T) {
 			},
 			ExpectedErrorString: "the Kubernetes release version in the title",
 		},
+		{
+			PullRequest: &PullRequest{
+				PullRequestQuery: PullRequestQuery{
+					Title: githubql.String("conformance results for v1.35.2/coolkube"),
+				},
+			},
+		},
+		{
+			PullRequest: &PullRequest{
+				PullRequestQuery: PullRequestQuery{
+					Title: githubql.String("conformance results for v1.3/coolkube"),
+				},
+			},
+			ExpectedErrorString: "the Kubernetes release version in the title (v1.3) and folder structure (v1.35)",
+		},
 	} {
 		prSuite := NewPRSuite(tc.PullRequest)
 		prSuite.KubernetesReleaseVersion = "v1.35"
@@ -1694,7 +1573,7 @@ func TestGetRequiredTests(t *testing.T) {
 			Name:                "invalid with malformed version",
 			Version:             "v1.notfound",
 			ExpectedTestsCount:  0,
-			ExpectedErrorString: "malformed version",
+			ExpectedErrorString: "is not a release version like v1.35 or v1.35.2",
 		},
 		{
 			Name:                "invalid unable to parse conformance.yaml",
@@ -1708,13 +1587,14 @@ func TestGetRequiredTests(t *testing.T) {
 			Version:             "v1.30",
 			ExpectedTestsCount:  0,
 			MetadataFolder:      common.Pointer("testdata/metadata/bad-version-in-conformance.yaml"),
-			ExpectedErrorString: "malformed version",
+			ExpectedErrorString: "is not a release version like v1.35 or v1.35.2",
 		},
 	} {
 		t.Run(tc.Name, func(t *testing.T) {
 			prSuite := NewPRSuite(&PullRequest{})
 			prSuite.KubernetesReleaseVersion = tc.Version
 			if tc.MetadataFolder != nil {
+				prSuite.DataFS = os.DirFS(".")
 				prSuite.MetadataFolder = *tc.MetadataFolder
 			}
 			tests, err := prSuite.GetRequiredTests()
@@ -1802,6 +1682,7 @@ func TestGetMissingJunitTestsFromPRSuite(t *testing.T) {
 		prSuite := NewPRSuite(tc.PullRequest)
 		prSuite.KubernetesReleaseVersion = tc.Version
 		if tc.MetadataFolder != nil {
+			prSuite.DataFS = os.DirFS(".")
 			prSuite.MetadataFolder = *tc.MetadataFolder
 		}
 		tests, err := prSuite.GetMissingJunitTestsFromPRSuite()
@@ -1985,7 +1866,7 @@ func TestTheTestsPassAndAreSuccessful(t *testing.T) {
 				},
 			},
 			ExpectedLabels:      []string{"conformance-product-submission", "evidence-missing"},
-			ExpectedErrorString: "it appears that there are failures in some tests",
+			ExpectedErrorString: "it appears that there are failures in some tests in the junit_01.xml",
 		},
 		{
 			Name: "invalid with missing junit_01.xml",
@@ -2086,6 +1967,7 @@ func TestAllRequiredTestsInArePresent(t *testing.T) {
 		prSuite := NewPRSuite(tc.PullRequest)
 		prSuite.KubernetesReleaseVersion = "v1.35"
 		if tc.MetadataFolder != nil {
+			prSuite.DataFS = os.DirFS(".")
 			prSuite.MetadataFolder = *tc.MetadataFolder
 		}
 		if err := prSuite.allRequiredTestsInArePresent(); err != nil && !strings.Contains(err.Error(), tc.ExpectedErrorString) {
@@ -2132,6 +2014,119 @@ d: e
 	}
 }
 
+func TestIsValidXml(t *testing.T) {
+	type testCase struct {
+		Name                string
+		Content             string
+		ExpectedErrorString string
+	}
+
+	for _, tc := range []testCase{
+		{
+			Name:    "valid xml",
+			Content: `<?xml version="1.0" encoding="UTF-8"?><a><b>c</b></a>`,
+		},
+		{
+			Name:                "unclosed element",
+			Content:             `<a><b>c</a>`,
+			ExpectedErrorString: "element <b> closed by </a>",
+		},
+		{
+			Name:                "truncated",
+			Content:             `<a><b>c</b>`,
+			ExpectedErrorString: "unexpected EOF",
+		},
+		{
+			Name:                "not xml",
+			Content:             `cool!`,
+			ExpectedErrorString: "no root element found",
+		},
+	} {
+		err := IsValidXml([]byte(tc.Content))
+		if tc.ExpectedErrorString == "" && err != nil {
+			t.Fatalf("error on test '%v'; unexpected error: %v", tc.Name, err)
+		}
+		if tc.ExpectedErrorString != "" && (err == nil || !strings.Contains(err.Error(), tc.ExpectedErrorString)) {
+			t.Fatalf("error on test '%v'; expected error containing '%v'; got = %v", tc.Name, tc.ExpectedErrorString, err)
+		}
+	}
+}
+
+func TestIsValidJunitXml(t *testing.T) {
+	type testCase struct {
+		Name                string
+		Content             string
+		ExpectedErrorString string
+	}
+
+	for _, tc := range []testCase{
+		{
+			Name:    "valid junit",
+			Content: testGetJunitSubmittedConformanceTestsCoolkubeV133Junit_01xml,
+		},
+		{
+			Name: "valid junit without counts",
+			Content: `<testsuites>
+  <testsuite name="Kubernetes e2e suite">
+    <testcase name="[It] [sig-node] Pods should be updated [NodeConformance] [Conformance]"></testcase>
+  </testsuite>
+</testsuites>`,
+		},
+		{
+			Name:                "malformed",
+			Content:             `<testsuites><testsuite></testsuites>`,
+			ExpectedErrorString: "element <testsuite> closed by </testsuites>",
+		},
+		{
+			Name:                "missing testsuites root",
+			Content:             `<testsuite name="Kubernetes e2e suite"></testsuite>`,
+			ExpectedErrorString: "the root element is <testsuite>, expected <testsuites>",
+		},
+		{
+			Name:                "no testsuite",
+			Content:             `<testsuites></testsuites>`,
+			ExpectedErrorString: "no <testsuite> elements found",
+		},
+		{
+			Name:                "failures attribute disagrees",
+			Content:             testGetJunitSubmittedConformanceTestsCoolkubeV133Junit_01WithOneTestFailedxml,
+			ExpectedErrorString: `the failures attribute of <testsuite name="Kubernetes e2e suite"> (0) does not match the number of <testcase> elements with a <failure> (1)`,
+		},
+		{
+			Name:                "tests attribute disagrees",
+			Content:             testGetJunitSubmittedConformanceTestsCoolkubeV133Junit_01WithOneTestMissingxml,
+			ExpectedErrorString: `the tests attribute of <testsuite name="Kubernetes e2e suite"> (7353) does not match the number of <testcase> elements (7352)`,
+		},
+		{
+			Name: "tests attribute of testsuites disagrees",
+			Content: `<testsuites tests="2">
+  <testsuite name="Kubernetes e2e suite">
+    <testcase name="[It] [sig-node] Pods should be updated [NodeConformance] [Conformance]"></testcase>
+  </testsuite>
+</testsuites>`,
+			ExpectedErrorString: "the tests attribute of <testsuites> (2) does not match the number of <testcase> elements (1)",
+		},
+		{
+			Name: "no conformance tests",
+			Content: `<testsuites>
+  <testsuite name="Kubernetes e2e suite">
+    <testcase name="[It] [sig-node] Pods should be updated [NodeConformance] [Conformance]"><skipped message="skipped"></skipped></testcase>
+    <testcase name="[It] [sig-node] Pods should be cool"></testcase>
+  </testsuite>
+</testsuites>`,
+			ExpectedErrorString: "no [Conformance] tests were run",
+		},
+	} {
+		err := IsValidJunitXml([]byte(tc.Content))
+		if tc.ExpectedErrorString == "" && err != nil {
+			t.Fatalf("error on test '%v'; unexpected error: %v", tc.Name, err)
+		}
+		if tc.ExpectedErrorString != "" && (err == nil || !strings.Contains(err.Error(), tc.ExpectedErrorString)) {
+			t.Fatalf("error on test '%v'; expected error containing '%v'; got = %v", tc.Name, tc.ExpectedErrorString, err)
+		}
+	}
+}
+
 func TestIsValid(t *testing.T) {
 	type testCase struct {
 		Name                string
@@ -2205,6 +2200,61 @@ contact_email_address: "greetings@cool.kube"`,
 				},
 			},
 		},
+		{
+			Name:     "valid junit",
+			File:     "junit_01.xml",
+			FileType: "xml",
+			PullRequest: &PullRequest{
+				SupportingFiles: []*PullRequestFile{
+					{
+						BaseName: "junit_01.xml",
+						Contents: testGetJunitSubmittedConformanceTestsCoolkubeV133Junit_01xml,
+					},
+				},
+			},
+		},
+		{
+			Name:     "malformed junit",
+			File:     "junit_01.xml",
+			FileType: "xml",
+			PullRequest: &PullRequest{
+				SupportingFiles: []*PullRequestFile{
+					{
+						BaseName: "junit_01.xml",
+						Contents: `<testsuites><testsuite>`,
+					},
+				},
+			},
+			ExpectedErrorString: "failed to parse (junit_01.xml) XML, XML syntax error",
+		},
+		{
+			Name:     "invalid junit",
+			File:     "junit_01.xml",
+			FileType: "xml",
+			PullRequest: &PullRequest{
+				SupportingFiles: []*PullRequestFile{
+					{
+						BaseName: "junit_01.xml",
+						Contents: `<testsuite></testsuite>`,
+					},
+				},
+			},
+			ExpectedErrorString: "(junit_01.xml) is not a valid junit report of a conformance test run, the root element is",
+		},
+		{
+			Name:     "unclosed code block in markdown",
+			File:     "README.md",
+			FileType: "markdown",
+			PullRequest: &PullRequest{
+				SupportingFiles: []*PullRequestFile{
+					{
+						BaseName: "README.md",
+						Contents: "# Hi!\n\n~~~sh\ncoolkube up",
+					},
+				},
+			},
+			ExpectedErrorString: "failed to parse (README.md) markdown, the code block opened on line 3 is not closed",
+		},
 		{
 			Name:     "missing README.md",
 			File:     "README.md",
@@ -2250,7 +2300,7 @@ func TestGetLabelsAndCommentsFromSuiteResultsBuffer(t *testing.T) {
 				SupportingFiles:         []*PullRequestFile{},
 				ProductYAMLURLDataTypes: map[string]string{},
 			},
-			ExpectedErrorString: "malformed version",
+			ExpectedErrorString: "is not a release version like v1.35 or v1.35.2",
 		},
 		{
 			Name:              "invalid with KubernetesVersion",
@@ -2380,12 +2430,12 @@ contact_email_address: "sales@coolkubernetes.com"`,
 					{
 						Name:     "v1.35/coolkube/README.md",
 						BaseName: "README.md",
-						Contents: `# v1.35/coolkube`,
+						Contents: testReadmeCoolkubeV135README,
 					},
 					{
 						Name:     "v1.35/coolkube/e2e.log",
 						BaseName: "e2e.log",
-						Contents: `stuff here`,
+						Contents: testParseE2eLogCoolkubeV135E2eLog,
 					},
 					{
 						Name:     "v1.35/coolkube/junit_01.xml",
@@ -2396,7 +2446,7 @@ contact_email_address: "sales@coolkubernetes.com"`,
 				ProductYAMLURLDataTypes: map[string]string{},
 			},
 			ExpectedLabels:  []string{"conformance-product-submission", "tests-verified-v1.35", "no-failed-tests-v1.35", "release-v1.35", "release-documents-checked"},
-			ExpectedComment: common.Pointer("All requirements (15) have passed for the submission!\n"),
+			ExpectedComment: common.Pointer("All requirements (20) have passed for the submission!\n"),
 		},
 	} {
 		prSuite := NewPRSuite(tc.PullRequest)
@@ -2407,7 +2457,7 @@ contact_email_address: "sales@coolkubernetes.com"`,
 			prSuite.KubernetesReleaseVersionLatest = *tc.KubernetesVersionLatest
 		}
 		prSuite.SetSubmissionMetadatafromFolderStructure()
-		prSuite.NewTestSuite(PRSuiteOptions{Paths: []string{"../../kodata/features/verify-conformance.feature"}}).Run()
+		prSuite.NewTestSuite(PRSuiteOptions{}).Run()
 		if tc.Buffer != nil {
 			prSuite.buffer = *tc.Buffer
 		}
@@ -2429,7 +2479,7 @@ contact_email_address: "sales@coolkubernetes.com"`,
 
 func TestInitializeScenario(t *testing.T) {
 	prSuite := NewPRSuite(&PullRequest{})
-	prSuite.NewTestSuite(PRSuiteOptions{Paths: []string{"../../kodata/features/verify-conformance.feature"}})
+	prSuite.NewTestSuite(PRSuiteOptions{})
 	if code := prSuite.Suite.Run(); code != 1 {
 		t.Fatalf("error intended failure code of '1', but found to be '%v'", code)
 	}
@@ -0,0 +1,150 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package verify
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	sonobuoyresults "github.com/vmware-tanzu/sonobuoy/pkg/client/results"
+)
+
+const (
+	e2eLogFileName   = "e2e.log"
+	junitXMLFileName = "junit_01.xml"
+)
+
+var (
+	// sonobuoyE2EResultsDir is where the e2e plugin writes its results in a Sonobuoy archive
+	sonobuoyE2EResultsDir = path.Join(sonobuoyresults.PluginsDir, "e2e", sonobuoyresults.ResultsDir, "global")
+)
+
+// Results are the files produced by a conformance test run which make up a submission
+type Results struct {
+	E2eLog   []byte
+	JunitXML []byte
+}
+
+// ReadResults reads the results from a Sonobuoy results tarball, an extracted Sonobuoy
+// results directory or a Hydrophone output directory
+func ReadResults(resultsPath string) (*Results, error) {
+	info, err := os.Stat(resultsPath)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return ReadSonobuoyResults(resultsPath)
+	}
+	if _, err := os.Stat(filepath.Join(resultsPath, filepath.FromSlash(sonobuoyE2EResultsDir))); err == nil {
+		return readSonobuoyResultsFromReader(sonobuoyresults.NewReaderFromDir(resultsPath))
+	}
+	return ReadHydrophoneResults(resultsPath)
+}
+
+// ReadSonobuoyResults reads the e2e plugin results from a Sonobuoy results tarball, as made by `sonobuoy retrieve`
+func ReadSonobuoyResults(tarball string) (*Results, error) {
+	data, err := os.ReadFile(tarball)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := sonobuoyresults.NewReaderFromBytes(data)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read Sonobuoy results '%v', %v", tarball, err)
+	}
+	return readSonobuoyResultsFromReader(reader)
+}
+
+func readSonobuoyResultsFromReader(reader *sonobuoyresults.Reader) (*Results, error) {
+	var e2eLog, junitXML bytes.Buffer
+	err := reader.WalkFiles(func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := sonobuoyresults.ExtractBytes(path.Join(sonobuoyE2EResultsDir, e2eLogFileName), filePath, info, &e2eLog); err != nil {
+			return err
+		}
+		return sonobuoyresults.ExtractBytes(path.Join(sonobuoyE2EResultsDir, junitXMLFileName), filePath, info, &junitXML)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to read Sonobuoy results, %v", err)
+	}
+	results := &Results{E2eLog: e2eLog.Bytes(), JunitXML: junitXML.Bytes()}
+	if err := results.validate(); err != nil {
+		return nil, fmt.Errorf("%v in the e2e plugin results of the Sonobuoy archive (%v)", err, sonobuoyE2EResultsDir)
+	}
+	return results, nil
+}
+
+// ReadHydrophoneResults reads the results from a Hydrophone output directory
+func ReadHydrophoneResults(dir string) (*Results, error) {
+	results := &Results{}
+	var err error
+	if results.E2eLog, err = readOptionalFile(filepath.Join(dir, e2eLogFileName)); err != nil {
+		return nil, err
+	}
+	if results.JunitXML, err = readOptionalFile(filepath.Join(dir, junitXMLFileName)); err != nil {
+		return nil, err
+	}
+	if err := results.validate(); err != nil {
+		return nil, fmt.Errorf("%v in the Hydrophone output directory '%v'", err, dir)
+	}
+	return results, nil
+}
+
+func readOptionalFile(name string) ([]byte, error) {
+	content, err := os.ReadFile(name)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return content, err
+}
+
+func (r *Results) validate() error {
+	switch {
+	case len(r.E2eLog) == 0 && len(r.JunitXML) == 0:
+		return fmt.Errorf("unable to find %v or %v", e2eLogFileName, junitXMLFileName)
+	case len(r.E2eLog) == 0:
+		return fmt.Errorf("unable to find %v", e2eLogFileName)
+	case len(r.JunitXML) == 0:
+		return fmt.Errorf("unable to find %v", junitXMLFileName)
+	}
+	return nil
+}
+
+// Files returns the contents of the results by their file name in a submission
+func (r *Results) Files() map[string][]byte {
+	return map[string][]byte{
+		e2eLogFileName:   r.E2eLog,
+		junitXMLFileName: r.JunitXML,
+	}
+}
+
+// WriteTo writes the results into the submission directory dir with the names required for a submission
+func (r *Results) WriteTo(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	for name, content := range r.Files() {
+		if err := os.WriteFile(filepath.Join(dir, name), content, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package verify
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeSonobuoyTarball writes a results tarball with files, as made by `sonobuoy retrieve`
+func writeSonobuoyTarball(t *testing.T, files map[string]string) string {
+	tarball := filepath.Join(t.TempDir(), "202410170000_sonobuoy_results.tar.gz")
+	f, err := os.Create(tarball)
+	if err != nil {
+		t.Fatalf("error: creating tarball: %v", err)
+	}
+	defer f.Close()
+	gw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gw)
+	files["meta/config.json"] = `{"Version":"v0.56.10"}`
+	for name, content := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content))}); err != nil {
+			t.Fatalf("error: writing tar header: %v", err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatalf("error: writing tar content: %v", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("error: closing tar: %v", err)
+	}
+	if err := gw.Close(); err != nil {
+		t.Fatalf("error: closing gzip: %v", err)
+	}
+	return tarball
+}
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatalf("error: creating directory: %v", err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatalf("error: writing file: %v", err)
+		}
+	}
+}
+
+func TestReadResults(t *testing.T) {
+	sonobuoyFiles := func() map[string]string {
+		return map[string]string{
+			"plugins/e2e/results/global/e2e.log":      "the e2e log",
+			"plugins/e2e/results/global/junit_01.xml": "<testsuites></testsuites>",
+			"plugins/e2e/sonobuoy_results.yaml":       "name: e2e",
+			"plugins/systemd-logs/results/e2e.log":    "not this one",
+		}
+	}
+	extractedDir := t.TempDir()
+	writeFiles(t, extractedDir, sonobuoyFiles())
+	hydrophoneDir := t.TempDir()
+	writeFiles(t, hydrophoneDir, map[string]string{
+		"e2e.log":      "the e2e log",
+		"junit_01.xml": "<testsuites></testsuites>",
+	})
+	missingJunitDir := t.TempDir()
+	writeFiles(t, missingJunitDir, map[string]string{"e2e.log": "the e2e log"})
+
+	tests := []struct {
+		name    string
+		path    string
+		wantErr string
+	}{
+		{
+			name: "sonobuoy tarball",
+			path: writeSonobuoyTarball(t, sonobuoyFiles()),
+		},
+		{
+			name: "extracted sonobuoy results",
+			path: extractedDir,
+		},
+		{
+			name: "hydrophone output directory",
+			path: hydrophoneDir,
+		},
+		{
+			name:    "sonobuoy tarball without e2e results",
+			path:    writeSonobuoyTarball(t, map[string]string{"plugins/systemd-logs/results/e2e.log": "not this one"}),
+			wantErr: "unable to find e2e.log or junit_01.xml in the e2e plugin results",
+		},
+		{
+			name:    "hydrophone output directory missing junit",
+			path:    missingJunitDir,
+			wantErr: "unable to find junit_01.xml in the Hydrophone output directory",
+		},
+		{
+			name:    "not a tarball",
+			path:    "./testdata/v1.35/coolkube/README.md",
+			wantErr: "unable to read Sonobuoy results",
+		},
+		{
+			name:    "missing",
+			path:    "./testdata/missing.tar.gz",
+			wantErr: "no such file or directory",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			results, err := ReadResults(tc.path)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("error: expected error containing '%v'; got = %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error: unexpected error: %v", err)
+			}
+			if want, got := "the e2e log", string(results.E2eLog); want != got {
+				t.Fatalf("unexpected e2e.log: want = %v; got = %v", want, got)
+			}
+			if want, got := "<testsuites></testsuites>", string(results.JunitXML); want != got {
+				t.Fatalf("unexpected junit_01.xml: want = %v; got = %v", want, got)
+			}
+		})
+	}
+}
+
+func TestRunWithResults(t *testing.T) {
+	junitXML, err := os.ReadFile("./testdata/v1.35/coolkube/junit_01.xml")
+	if err != nil {
+		t.Fatalf("error: reading junit: %v", err)
+	}
+	e2eLog, err := os.ReadFile("./testdata/v1.35/coolkube/e2e.log")
+	if err != nil {
+		t.Fatalf("error: reading e2e.log: %v", err)
+	}
+	readme, err := os.ReadFile("./testdata/v1.35/coolkube/README.md")
+	if err != nil {
+		t.Fatalf("error: reading readme: %v", err)
+	}
+	productYAML, err := os.ReadFile("./testdata/v1.35/coolkube/PRODUCT.yaml")
+	if err != nil {
+		t.Fatalf("error: reading product yaml: %v", err)
+	}
+	tarball := writeSonobuoyTarball(t, map[string]string{
+		"plugins/e2e/results/global/e2e.log":      string(e2eLog),
+		"plugins/e2e/results/global/junit_01.xml": string(junitXML),
+	})
+
+	for _, write := range []bool{false, true} {
+		dir := filepath.Join(t.TempDir(), "v1.35", "coolkube")
+		writeFiles(t, dir, map[string]string{
+			"README.md":    string(readme),
+			"PRODUCT.yaml": string(productYAML),
+			"e2e.log":      "a stale log to be replaced",
+		})
+		result, err := Run(log, Options{Dir: dir, Results: tarball, WriteResults: write})
+		if err != nil {
+			t.Fatalf("error: unexpected error: %v", err)
+		}
+		if result.State != "success" {
+			t.Fatalf("error: unexpected state '%v' with comment: %v", result.State, result.Comment)
+		}
+		writtenE2eLog, err := os.ReadFile(filepath.Join(dir, "e2e.log"))
+		if err != nil {
+			t.Fatalf("error: reading e2e.log: %v", err)
+		}
+		if written := string(writtenE2eLog) == string(e2eLog); written != write {
+			t.Fatalf("error: expected results to be written = %v; got e2e.log = %v", write, string(writtenE2eLog))
+		}
+		if _, err := os.Stat(filepath.Join(dir, "junit_01.xml")); os.IsNotExist(err) == write {
+			t.Fatalf("error: expected junit_01.xml to be written = %v", write)
+		}
+	}
+}
@@ -0,0 +1,246 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package verify runs the checks of the bot against a submission on the local filesystem,
+// so that they may be run before opening a PR.
+package verify
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+
+	githubql "github.com/shurcooL/githubv4"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/verify-conformance/internal/common"
+	"sigs.k8s.io/verify-conformance/internal/plugin"
+	"sigs.k8s.io/verify-conformance/internal/suite"
+)
+
+const (
+	// tagsWithoutGitHub skips the scenarios which need a PR on GitHub, such as the commit count
+	tagsWithoutGitHub = "~@github"
+	// tagsWithoutNetwork also skips the scenarios which need to resolve URLs
+	tagsWithoutNetwork = "~@github && ~@network"
+)
+
+// Options configure the verification of a submission directory
+type Options struct {
+	// Dir is the submission directory, structured like [KubernetesReleaseVersion]/[ProductName]
+	Dir string
+	// Title is the title of the PR, inferred from Dir when empty
+	Title string
+	// CheckURLs resolves the URLs in PRODUCT.yaml, which requires network access
+	CheckURLs bool
+	// Results is a Sonobuoy results tarball or a Hydrophone output directory,
+	// used in place of the e2e.log and junit_01.xml in Dir
+	Results string
+	// WriteResults writes the e2e.log and junit_01.xml from Results into Dir
+	WriteResults bool
+	// Base is a local checkout of the base branch of the conformance repo,
+	// used to find the existing submissions which the submission may overwrite or duplicate
+	Base string
+}
+
+// TitleForDir returns the title for a submission in dir, like "Conformance results for v1.35/coolkube"
+func TitleForDir(dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Conformance results for %v/%v", filepath.Base(filepath.Dir(abs)), filepath.Base(abs)), nil
+}
+
+// NewPRSuiteForDir returns a PRSuite for the files in the submission directory dir,
+// named as they would be in a PR to the conformance repo.
+// The files from results, when set, are used in place of those in dir.
+func NewPRSuiteForDir(dir string, title string, results *Results) (*suite.PRSuite, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(abs)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("'%v' is not a directory", dir)
+	}
+	folder := path.Join(filepath.Base(filepath.Dir(abs)), filepath.Base(abs))
+
+	if title == "" {
+		if title, err = TitleForDir(abs); err != nil {
+			return nil, err
+		}
+	}
+	pr := &suite.PullRequest{}
+	pr.Title = githubql.String(title)
+	err = filepath.WalkDir(abs, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(abs, filePath)
+		if err != nil {
+			return err
+		}
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+		pr.SupportingFiles = append(pr.SupportingFiles, &suite.PullRequestFile{
+			Name:     path.Join(folder, filepath.ToSlash(rel)),
+			BaseName: d.Name(),
+			Contents: string(content),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to read submission directory '%v', %v", dir, err)
+	}
+	if results != nil {
+		setResultsFiles(pr, folder, results)
+	}
+
+	prSuite := suite.NewPRSuite(pr)
+	stableTxt, err := common.ReadStableTxt(prSuite.DataFS)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read latest version info")
+	}
+	prSuite.KubernetesReleaseVersionLatest = stableTxt
+	releaseLifecycle, err := suite.GetReleaseLifecycle(prSuite.DataFS)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the release lifecycle, %v", err)
+	}
+	prSuite.ReleaseLifecycle = releaseLifecycle
+	prSuite.SetSubmissionMetadatafromFolderStructure()
+	return prSuite, nil
+}
+
+// ExistingSubmissionsForDir returns the submissions for release in base, a local checkout of the conformance repo.
+// The folder at dir is left out, so that a submission isn't found as its own duplicate in the working tree.
+func ExistingSubmissionsForDir(base string, release string, dir string) ([]*suite.ExistingSubmission, error) {
+	existing := []*suite.ExistingSubmission{}
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(filepath.Join(base, release))
+	if os.IsNotExist(err) {
+		return existing, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the release folder '%v' in '%v', %v", release, base, err)
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		folder := filepath.Join(base, release, entry.Name())
+		if abs, err := filepath.Abs(folder); err == nil && abs == absDir {
+			continue
+		}
+		productYAML, err := os.ReadFile(filepath.Join(folder, "PRODUCT.yaml"))
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		existing = append(existing, suite.NewExistingSubmission(path.Join(release, entry.Name()), productYAML))
+	}
+	return existing, nil
+}
+
+// setResultsFiles replaces the results files at the top of the submission folder with results
+func setResultsFiles(pr *suite.PullRequest, folder string, results *Results) {
+	for name, content := range results.Files() {
+		file := &suite.PullRequestFile{
+			Name:     path.Join(folder, name),
+			BaseName: name,
+			Contents: string(content),
+		}
+		replaced := false
+		for i, f := range pr.SupportingFiles {
+			if f.Name == file.Name {
+				pr.SupportingFiles[i] = file
+				replaced = true
+			}
+		}
+		if !replaced {
+			pr.SupportingFiles = append(pr.SupportingFiles, file)
+		}
+	}
+	sort.Slice(pr.SupportingFiles, func(i, j int) bool {
+		return pr.SupportingFiles[i].Name < pr.SupportingFiles[j].Name
+	})
+}
+
+// Run checks the submission directory the same way the bot checks a PR
+func Run(log *logrus.Entry, opts Options) (*suite.Report, error) {
+	var results *Results
+	if opts.Results != "" {
+		var err error
+		if results, err = ReadResults(opts.Results); err != nil {
+			return nil, err
+		}
+		if opts.WriteResults {
+			if err := results.WriteTo(opts.Dir); err != nil {
+				return nil, fmt.Errorf("unable to write results into '%v', %v", opts.Dir, err)
+			}
+		}
+	}
+	prSuite, err := NewPRSuiteForDir(opts.Dir, opts.Title, results)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Base != "" {
+		if prSuite.PR.ExistingSubmissions, err = ExistingSubmissionsForDir(opts.Base, prSuite.KubernetesReleaseVersion, opts.Dir); err != nil {
+			return nil, err
+		}
+	}
+	tags := tagsWithoutNetwork
+	if opts.CheckURLs {
+		tags = tagsWithoutGitHub
+		if file := prSuite.GetFileByFileName("PRODUCT.yaml"); file != nil {
+			plugin.ResolveProductYAMLURLDataTypes(log, prSuite, file.Contents)
+		}
+	}
+
+	if err := prSuite.ItIsAValidAndSupportedRelease(); err != nil {
+		data := prSuite.NewCommentData()
+		data.Error = err.Error()
+		comment, err := prSuite.RenderComment(suite.CommentTemplateUnsupportedRelease, data)
+		if err != nil {
+			return nil, err
+		}
+		return prSuite.NewReport(comment, []string{"conformance-product-submission", "unable-to-process"}, "pending"), nil
+	}
+	if _, err := prSuite.ReadConformanceYAML(); errors.Is(err, fs.ErrNotExist) {
+		comment, err := prSuite.RenderComment(suite.CommentTemplateUnableToProcess, prSuite.NewCommentData())
+		if err != nil {
+			return nil, err
+		}
+		return prSuite.NewReport(comment, []string{"conformance-product-submission", "unable-to-process"}, "pending"), nil
+	}
+
+	prSuite.NewTestSuite(suite.PRSuiteOptions{Tags: tags}).Run()
+	return prSuite.GetReport()
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package verify
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+var log = logrus.StandardLogger().WithField("plugin", "verify-conformance")
+
+func TestTitleForDir(t *testing.T) {
+	title, err := TitleForDir("./testdata/v1.35/coolkube/")
+	if err != nil {
+		t.Fatalf("error: unexpected error: %v", err)
+	}
+	if want := "Conformance results for v1.35/coolkube"; title != want {
+		t.Fatalf("unexpected title: want = %v; got = %v", want, title)
+	}
+}
+
+func TestNewPRSuiteForDir(t *testing.T) {
+	prSuite, err := NewPRSuiteForDir("./testdata/v1.35/coolkube", "", nil)
+	if err != nil {
+		t.Fatalf("error: unexpected error: %v", err)
+	}
+	if want, got := "v1.35", prSuite.KubernetesReleaseVersion; want != got {
+		t.Fatalf("unexpected release version: want = %v; got = %v", want, got)
+	}
+	if want, got := "coolkube", prSuite.ProductName; want != got {
+		t.Fatalf("unexpected product name: want = %v; got = %v", want, got)
+	}
+	names := []string{}
+	for _, f := range prSuite.PR.SupportingFiles {
+		names = append(names, f.Name)
+	}
+	want := []string{
+		"v1.35/coolkube/PRODUCT.yaml",
+		"v1.35/coolkube/README.md",
+		"v1.35/coolkube/e2e.log",
+		"v1.35/coolkube/junit_01.xml",
+	}
+	if !reflect.DeepEqual(names, want) {
+		t.Fatalf("unexpected files: want = %v; got = %v", want, names)
+	}
+
+	if _, err := NewPRSuiteForDir("./testdata/v1.35/coolkube/README.md", "", nil); err == nil {
+		t.Fatalf("error: expected error for a file")
+	}
+	if _, err := NewPRSuiteForDir("./testdata/v1.35/missing", "", nil); err == nil {
+		t.Fatalf("error: expected error for a missing directory")
+	}
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        Options
+		wantTitle   string
+		wantState   string
+		wantComment string
+		wantLabels  []string
+	}{
+		{
+			name:        "valid submission",
+			opts:        Options{Dir: "./testdata/v1.35/coolkube"},
+			wantTitle:   "Conformance results for v1.35/coolkube",
+			wantState:   "success",
+			wantComment: "All requirements (17) have passed for the submission!",
+			wantLabels: []string{
+				"conformance-product-submission",
+				"tests-verified-v1.35",
+				"no-failed-tests-v1.35",
+				"release-v1.35",
+				"release-documents-checked",
+			},
+		},
+		{
+			name:        "title from flag",
+			opts:        Options{Dir: "./testdata/v1.35/coolkube", Title: "Conformance results for v1.34/coolkube"},
+			wantTitle:   "Conformance results for v1.34/coolkube",
+			wantState:   "failure",
+			wantComment: "the Kubernetes release version in the title (v1.34) and folder structure (v1.35)",
+		},
+		{
+			name:        "base without the submission",
+			opts:        Options{Dir: "./testdata/v1.35/coolkube", Base: "./testdata"},
+			wantTitle:   "Conformance results for v1.35/coolkube",
+			wantState:   "success",
+			wantComment: "All requirements (17) have passed for the submission!",
+		},
+		{
+			name:        "base with the submission",
+			opts:        Options{Dir: "./testdata/v1.35/coolkube", Base: "./testdata/base"},
+			wantTitle:   "Conformance results for v1.35/coolkube",
+			wantState:   "failure",
+			wantComment: "the folder &#39;v1.35/coolkube&#39; already exists",
+		},
+		{
+			name:        "title with a patch release",
+			opts:        Options{Dir: "./testdata/v1.35/coolkube", Title: "Conformance results for v1.35.2/coolkube"},
+			wantTitle:   "Conformance results for v1.35.2/coolkube",
+			wantState:   "success",
+			wantComment: "All requirements (17) have passed for the submission!\n\nThe submission is for the patch release v1.35.2 of v1.35.",
+		},
+		{
+			name:        "unsupported release",
+			opts:        Options{Dir: "./testdata/v1.20/oldkube"},
+			wantTitle:   "Conformance results for v1.20/oldkube",
+			wantState:   "pending",
+			wantComment: "v1.20",
+			wantLabels:  []string{"conformance-product-submission", "unable-to-process"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := Run(log, tc.opts)
+			if err != nil {
+				t.Fatalf("error: unexpected error: %v", err)
+			}
+			if result.Title != tc.wantTitle {
+				t.Fatalf("unexpected title: want = %v; got = %v", tc.wantTitle, result.Title)
+			}
+			if result.State != tc.wantState {
+				t.Fatalf("unexpected state: want = %v; got = %v\n%v", tc.wantState, result.State, result.Comment)
+			}
+			if !strings.Contains(result.Comment, tc.wantComment) {
+				t.Fatalf("unexpected comment: want to contain = %v; got = %v", tc.wantComment, result.Comment)
+			}
+			if tc.wantLabels != nil && !reflect.DeepEqual(result.Labels, tc.wantLabels) {
+				t.Fatalf("unexpected labels: want = %v; got = %v", tc.wantLabels, result.Labels)
+			}
+			if !strings.Contains(result.String(), "State: "+tc.wantState) {
+				t.Fatalf("unexpected output: %v", result.String())
+			}
+		})
+	}
+}
+
+func TestExistingSubmissionsForDir(t *testing.T) {
+	existing, err := ExistingSubmissionsForDir("./testdata/base", "v1.35", "./testdata/v1.35/coolkube")
+	if err != nil {
+		t.Fatalf("error: unexpected error: %v", err)
+	}
+	folders := []string{}
+	for _, e := range existing {
+		folders = append(folders, e.Folder)
+		if e.ProductYAML == nil {
+			t.Fatalf("error: expected the PRODUCT.yaml of '%v' to be read", e.Folder)
+		}
+	}
+	if want := []string{"v1.35/coolkube", "v1.35/warmkube"}; !reflect.DeepEqual(folders, want) {
+		t.Fatalf("error: unexpected folders; want = %v; got = %v", want, folders)
+	}
+
+	existing, err = ExistingSubmissionsForDir("./testdata", "v1.35", "./testdata/v1.35/coolkube")
+	if err != nil || len(existing) != 0 {
+		t.Fatalf("error: expected the submission folder itself to be left out; got = %v, %v", existing, err)
+	}
+	existing, err = ExistingSubmissionsForDir("./testdata", "v1.99", "./testdata/v1.35/coolkube")
+	if err != nil || existing == nil || len(existing) != 0 {
+		t.Fatalf("error: expected no existing submissions for a missing release; got = %v, %v", existing, err)
+	}
+}
@@ -28,7 +28,8 @@ Feature: verify conformance product submission PR
     the submission file directory does not seem to match the Kubernetes release version in the files
 
     Given the files in the PR
-    Then file folder structure matches "(v1.[0-9]{2})/(.*)"
+    Then file folder structure matches "({release})/(.*)"
+    # {release} is a Kubernetes release version, like v1.35
     # $1 is the release version of Kubernetes
     # $2 is the product name
     # example: v1.23/coolthing
@@ -46,22 +47,31 @@ Feature: verify conformance product submission PR
     And the title of the PR
     Then the release version matches the release version in the title
 
-  Scenario: the PRODUCT.yaml metadata contains all required fields
-    it appears that the PRODUCT.yaml file does not contain all the required fields (https://github.com/cncf/k8s-conformance/blob/master/instructions.md#productyaml)
+  Scenario: the product name in the title, folder structure and PRODUCT.yaml are consistent
+    the product name does not seem to be the same in the title of the submission, the name of its folder and the PRODUCT.yaml
 
-    Given a "PRODUCT.yaml" file
-    Then the yaml file "PRODUCT.yaml" contains the required and non-empty <field>
+    Given the title of the PR
+    And the files in the PR
+    Then the product name in the title, folder structure and PRODUCT.yaml match
 
-    Examples:
-      | field                   |
-      | "vendor"                |
-      | "name"                  |
-      | "version"               |
-      | "type"                  |
-      | "description"           |
-      | "website_url"           |
-      | "documentation_url"     |
-      | "contact_email_address" |
+  Scenario: submission does not overwrite or duplicate an existing submission
+    it appears that this product may already be certified for this release on the base branch, a maintainer will need to confirm whether the existing submission is meant to be replaced
+
+    Given the files in the PR
+    Then the submission does not overwrite or duplicate an existing submission
+
+  Scenario: the README.md contains instructions to reproduce the results
+    it appears that the README.md does not contain the instructions to reproduce the conformance results (https://github.com/cncf/k8s-conformance/blob/master/instructions.md#readme)
+
+    Given a "README.md" file
+    Then the README.md contains instructions to reproduce the results
+
+  Scenario: the PRODUCT.yaml metadata is valid
+    it appears that the PRODUCT.yaml file is not valid (https://github.com/cncf/k8s-conformance/blob/master/instructions.md#productyaml)
+
+    Given a "PRODUCT.yaml" file
+    Then the PRODUCT.yaml is valid against its schema
+    # the schema is kodata/schemas/product-yaml-v1.schema.json
 
   Scenario: the URL and email fields in the PRODUCT.yaml are valid
     it appears that field(s) in the PRODUCT.yaml aren't correctly formatted
@@ -77,6 +87,7 @@ Feature: verify conformance product submission PR
       | "product_logo_url"      | "URL"       |
       | "contact_email_address" | "email"     |
 
+  @network
   Scenario: the URL fields in the PRODUCT.yaml resolve to their specified data types
     it appears that URL(s) in the PRODUCT.yaml don't resolve to the correct data type
 
@@ -89,19 +100,21 @@ Feature: verify conformance product submission PR
       | "repo_url"          | "text/html"                        |
       | "documentation_url" | "text/html"                        |
 
-  Scenario: the type field in PRODUCT.yaml is valid
-    it appears that the type field does not match either "distribution", "hosted platform" or "installer"
+  @network
+  Scenario: the product logo in the PRODUCT.yaml is a safe SVG
+    it appears that the product_logo_url in the PRODUCT.yaml does not resolve to a safe and well-formed SVG
 
     Given a "PRODUCT.yaml" file
-    Then the field "type" matches one of the following values: distribution, hosted platform, installer
+    Then the product logo is a safe SVG
 
   Scenario: title of product submission contains Kubernetes release version and product name
     the submission title is missing either a Kubernetes release version (v1.xx) or product name
 
     Given the title of the PR
-    Then the title of the PR matches "(.*) (v1.[0-9]{2})[ /](.*)"
+    Then the title of the PR matches "(.*) ({release})[ /](.*)"
+    # {release} is a Kubernetes release version, like v1.35 or v1.35.2
     # $1 is the string for conformance results for
-    # $2 is the version of Kubernetes
+    # $2 is the version of Kubernetes, with an optional patch
     # $3 is the product name
     # example: Conformance test for v1.23 Cool Engine
 
@@ -125,6 +138,20 @@ Feature: verify conformance product submission PR
     Then the tests pass and are successful
     And all required tests are present
 
+  Scenario: the e2e.log is from a run of the submission release version
+    it appears that the e2e.log is not from a test run against the Kubernetes release version of the submission
+
+    Given an "e2e.log" file
+    Then the Kubernetes release version in the e2e.log matches the release version
+
+  Scenario: the e2e.log results match the junit_01.xml
+    it appears that the e2e.log and junit_01.xml are not from the same test run
+
+    Given an "e2e.log" file
+    And a "junit_01.xml" file
+    Then the results in the e2e.log match the junit_01.xml
+
+  @github
   Scenario: there is only one commit
     it appears that there is not exactly one commit. Please rebase and squash with `git rebase -i HEAD` (https://git-scm.com/docs/git-rebase)
 
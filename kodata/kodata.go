@@ -0,0 +1,26 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package kodata embeds the default feature files, metadata, schemas, comment templates and conformance metadata,
+// so that the binary works from any working directory without ko or a checkout of the repo.
+package kodata
+
+import "embed"
+
+// FS is the contents of kodata, laid out as features/, metadata/, schemas/, templates/ and conformance-testdata/
+//
+//go:embed features metadata schemas templates conformance-testdata
+var FS embed.FS
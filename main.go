@@ -20,7 +20,9 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -28,8 +30,12 @@ import (
 	"sigs.k8s.io/prow/pkg/config/secret"
 	prowflagutil "sigs.k8s.io/prow/pkg/flagutil"
 	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/interrupts"
+	"sigs.k8s.io/prow/pkg/pluginhelp/externalplugins"
 	"sigs.k8s.io/prow/pkg/plugins"
 
+	"sigs.k8s.io/verify-conformance/internal/common"
+	"sigs.k8s.io/verify-conformance/internal/metadata"
 	"sigs.k8s.io/verify-conformance/internal/plugin"
 )
 
@@ -43,9 +49,15 @@ type options struct {
 	repo            string
 	prEventJSONPath string
 	dryRun          bool
+	checksAPI       bool
 	github          prowflagutil.GitHubOptions
 
 	updatePeriod time.Duration
+	workers      int
+
+	dataDir             string
+	metadataDir         string
+	metadataWatchPeriod time.Duration
 
 	webhookSecretFile string
 }
@@ -63,6 +75,20 @@ func (o *options) Validate() error {
 	if len(strings.Split(o.repo, "/")) != 2 {
 		return fmt.Errorf("repo must be formatted as ORG/NAME")
 	}
+	if o.updatePeriod <= 0 {
+		return fmt.Errorf("update-period must be greater than zero")
+	}
+	if o.workers <= 0 {
+		return fmt.Errorf("workers must be greater than zero")
+	}
+	if o.metadataWatchPeriod <= 0 {
+		return fmt.Errorf("metadata-watch-period must be greater than zero")
+	}
+	if o.dataDir != "" {
+		if info, err := os.Stat(o.dataDir); err != nil || !info.IsDir() {
+			return fmt.Errorf("data-dir must be a folder laid out like kodata")
+		}
+	}
 
 	return nil
 }
@@ -74,7 +100,12 @@ func gatherOptions() options {
 	fs.StringVar(&o.repo, "repo", "", "GitHub repo to use (i.e: 'cncf/k8s-conformance' or 'cncf-infra/k8s-conformance').")
 	fs.StringVar(&o.prEventJSONPath, "pr-event-json-path", "", "path to a GitHub workflow event.json file")
 	fs.BoolVar(&o.dryRun, "dry-run", true, "Dry run for testing. Uses API tokens but does not mutate.")
+	fs.BoolVar(&o.checksAPI, "checks-api", false, "Report results as a check run with annotations on the files instead of a commit status. Requires authenticating as a GitHub App with the checks write permission.")
 	fs.DurationVar(&o.updatePeriod, "update-period", time.Hour*24, "Period duration for periodic scans of all PRs.")
+	fs.IntVar(&o.workers, "workers", plugin.DefaultQueueWorkers, "Number of PRs to check at the same time.")
+	fs.StringVar(&o.dataDir, "data-dir", "", "Folder laid out like kodata to use instead of the kodata built into the binary.")
+	fs.StringVar(&o.metadataDir, "metadata-dir", "", "Folder laid out like kodata, such as a mounted ConfigMap, whose features, templates, conformance-testdata and metadata folders replace those of kodata when they change and are valid.")
+	fs.DurationVar(&o.metadataWatchPeriod, "metadata-watch-period", metadata.DefaultWatchPeriod, "Period duration for checking --metadata-dir for changes.")
 	fs.StringVar(&o.webhookSecretFile, "hmac-secret-file", "/etc/webhook/hmac", "Path to the file containing the GitHub HMAC secret.")
 
 	for _, group := range []prowflagutil.OptionGroup{&o.github} {
@@ -87,6 +118,12 @@ func gatherOptions() options {
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "verify" {
+		os.Exit(runVerify(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "metadata" {
+		os.Exit(runMetadata(os.Args[2:]))
+	}
 	o := gatherOptions()
 	if err := o.Validate(); err != nil {
 		logrus.Fatalf("Invalid options: %v", err)
@@ -95,6 +132,10 @@ func main() {
 	logrus.SetFormatter(&logrus.JSONFormatter{})
 	logrus.SetLevel(logrus.InfoLevel)
 	log := logrus.StandardLogger().WithField("plugin", pluginName)
+	if o.dataDir != "" {
+		common.SetDataFS(os.DirFS(o.dataDir))
+	}
+	plugin.UseChecksAPI = o.checksAPI
 
 	secrets := []string{}
 	if o.github.TokenPath != "" {
@@ -130,7 +171,6 @@ func main() {
 				logrus.WithError(err).Fatal("Error unmarshalling PR event.json file.")
 				return
 			}
-			// TODO: resolve issue with org repo name (https://github.com/cncf-infra/verify-conformance/issues/180)
 			if err := plugin.HandlePullRequestEvent(log, githubClient, &pre); err != nil {
 				log.WithError(err).Info("Error handling event.")
 			}
@@ -140,7 +180,27 @@ func main() {
 			return
 		}
 	}
-	if err := plugin.HandleAll(log, githubClient, &plugins.Configuration{
+	queue := plugin.NewQueue(log, githubClient, o.workers)
+	interrupts.Run(queue.Run)
+
+	// the metadata is loaded before the first sweep, which checks all PRs against it anyway
+	var resync *plugin.Resync
+	var watcher *metadata.Watcher
+	if o.metadataDir != "" {
+		watcher = metadata.NewWatcher(log, metadata.WatcherOptions{
+			Dir:    o.metadataDir,
+			Period: o.metadataWatchPeriod,
+			// PRs are checked again against the new metadata
+			OnSwitch: func() {
+				if resync != nil {
+					resync.Trigger()
+				}
+			},
+		})
+		watcher.Load()
+	}
+
+	resync = plugin.NewResync(log, githubClient, queue, &plugins.Configuration{
 		ExternalPlugins: map[string][]plugins.ExternalPlugin{
 			o.repo: {{
 				Name: pluginName,
@@ -150,7 +210,20 @@ func main() {
 				},
 			}},
 		},
-	}); err != nil {
-		log.WithError(err).Error("Error during periodic update of all PRs.")
+	}, o.updatePeriod)
+	if watcher != nil {
+		interrupts.Run(watcher.Run)
 	}
+	interrupts.Run(resync.Run)
+
+	mux := http.NewServeMux()
+	server := plugin.NewServer(log, githubClient, queue, secret.GetTokenGenerator(o.webhookSecretFile))
+	// events being handled when shutting down are finished first
+	interrupts.OnInterrupt(server.Shutdown)
+	mux.Handle("/", server)
+	externalplugins.ServeExternalPluginHelp(mux, log, plugin.HelpProvider)
+	httpServer := &http.Server{Addr: ":" + strconv.Itoa(o.port), Handler: mux}
+	log.Infof("Listening for webhooks on :%v", o.port)
+	interrupts.ListenAndServe(httpServer, 5*time.Second)
+	interrupts.WaitForGracefulShutdown()
 }
@@ -0,0 +1,66 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/verify-conformance/internal/metadata"
+)
+
+const metadataSyncUsage = `Usage: %v metadata sync [flags]
+
+Fetches stable.txt and the conformance.yaml of every release supported by
+kodata/metadata/release-lifecycle.yaml, validates them and writes a checksum manifest,
+then swaps them into kodata/conformance-testdata. Nothing is changed when any of it fails.
+
+`
+
+// runMetadata runs the metadata subcommand, returning the exit code
+func runMetadata(args []string) int {
+	if len(args) == 0 || args[0] != "sync" {
+		fmt.Fprintf(os.Stderr, metadataSyncUsage, os.Args[0])
+		return 2
+	}
+	opts := metadata.Options{}
+	fs := flag.NewFlagSet(os.Args[0]+" metadata sync", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), metadataSyncUsage, os.Args[0])
+		fs.PrintDefaults()
+	}
+	fs.StringVar(&opts.DataPath, "data-path", "kodata", "Path to the kodata folder to write to.")
+	fs.StringVar(&opts.ConformanceBaseURL, "conformance-base-url", metadata.DefaultConformanceBaseURL, "Base URL of the Kubernetes repo to fetch release-1.x/test/conformance/testdata/conformance.yaml from.")
+	fs.StringVar(&opts.StableTxtBaseURL, "stable-txt-base-url", metadata.DefaultStableTxtBaseURL, "Base URL to fetch stable.txt from.")
+	if err := fs.Parse(args[1:]); err != nil {
+		logrus.WithError(err).Fatal("error parsing args")
+	}
+
+	log := logrus.StandardLogger().WithField("plugin", pluginName)
+	result, err := metadata.Sync(context.Background(), opts)
+	if err != nil {
+		log.WithError(err).Error("Error syncing conformance metadata.")
+		return 1
+	}
+	log.WithField("stable", result.StableTxt).Infof("Synced conformance metadata for %v", strings.Join(result.Releases, ", "))
+	return 0
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/verify-conformance/internal/common"
+	"sigs.k8s.io/verify-conformance/internal/suite"
+	"sigs.k8s.io/verify-conformance/internal/verify"
+)
+
+const verifyUsage = `Usage: %v verify [flags] DIR
+
+Runs the checks of the bot against the submission in DIR, structured like v1.35/myproduct,
+printing the comment, labels and state the bot would set on the PR.
+It exits non-zero unless all checks pass.
+
+`
+
+var reportFormats = map[string]func(*suite.Report) ([]byte, error){
+	"text": func(r *suite.Report) ([]byte, error) {
+		return []byte(r.String()), nil
+	},
+	"json":  (*suite.Report).JSON,
+	"junit": (*suite.Report).JUnit,
+	"sarif": (*suite.Report).SARIF,
+}
+
+// runVerify runs the verify subcommand, returning the exit code
+func runVerify(args []string) int {
+	opts := verify.Options{}
+	var output, dataDir string
+	fs := flag.NewFlagSet(os.Args[0]+" verify", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), verifyUsage, os.Args[0])
+		fs.PrintDefaults()
+	}
+	fs.StringVar(&output, "output", "text", "Format of the report, one of: text, json, junit, sarif.")
+	fs.StringVar(&opts.Title, "title", "", "Title of the PR. Inferred from DIR when empty, i.e: 'Conformance results for v1.35/myproduct'.")
+	fs.StringVar(&opts.Results, "results", "", "Sonobuoy results tarball, or Hydrophone output directory, to take e2e.log and junit_01.xml from instead of DIR.")
+	fs.BoolVar(&opts.WriteResults, "write-results", false, "Write e2e.log and junit_01.xml from --results into DIR.")
+	fs.BoolVar(&opts.CheckURLs, "check-urls", false, "Resolve the URLs in PRODUCT.yaml to check their content type. Requires network access.")
+	fs.StringVar(&dataDir, "data-dir", "", "Folder laid out like kodata to use instead of the kodata built into the binary.")
+	fs.StringVar(&opts.Base, "base", "", "Local checkout of the base branch of cncf/k8s-conformance, to check whether the submission overwrites or duplicates an existing one.")
+	if err := fs.Parse(args); err != nil {
+		logrus.WithError(err).Fatal("error parsing args")
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+	opts.Dir = fs.Arg(0)
+	format, ok := reportFormats[output]
+	if !ok {
+		fmt.Fprintf(fs.Output(), "unknown --output '%v'\n", output)
+		return 2
+	}
+	if opts.WriteResults && opts.Results == "" {
+		fmt.Fprintln(fs.Output(), "--write-results requires --results")
+		return 2
+	}
+
+	if dataDir != "" {
+		common.SetDataFS(os.DirFS(dataDir))
+	}
+
+	log := logrus.StandardLogger().WithField("plugin", pluginName)
+	report, err := verify.Run(log, opts)
+	if err != nil {
+		log.WithError(err).Error("Error verifying submission.")
+		return 1
+	}
+	out, err := format(report)
+	if err != nil {
+		log.WithError(err).Error("Error formatting report.")
+		return 1
+	}
+	fmt.Println(string(out))
+	if report.State != "success" {
+		return 1
+	}
+	return 0
+}